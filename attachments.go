@@ -0,0 +1,321 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Attachment represents a file attached to a JIRA issue
+type Attachment struct {
+	ID       string    `json:"id"`
+	Filename string    `json:"filename"`
+	Author   *User     `json:"author,omitempty"`
+	Created  time.Time `json:"created"`
+	Size     int64     `json:"size"`
+	MimeType string    `json:"mimeType,omitempty"`
+	Content  string    `json:"content"` // download URL
+}
+
+// UnmarshalJSON decodes an attachment, parsing the JIRA timestamp
+func (a *Attachment) UnmarshalJSON(data []byte) error {
+	type plain Attachment
+	var raw struct {
+		*plain
+		Created string `json:"created"`
+	}
+	raw.plain = (*plain)(a)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	if a.Created, err = parseJiraTime(raw.Created); err != nil {
+		return fmt.Errorf("attachment %s: %v", a.ID, err)
+	}
+	return nil
+}
+
+// AuthorName returns the display name of the user who uploaded the attachment
+func (a *Attachment) AuthorName() string {
+	return userName(a.Author)
+}
+
+// newTransferClient returns the HTTP client for attachment transfers. http.Client.Timeout
+// also covers reading and writing the body, which would cut large files off partway, so
+// only connecting and waiting for the response headers are limited by timeout.
+func newTransferClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}).DialContext
+	transport.TLSHandshakeTimeout = timeout
+	transport.ResponseHeaderTimeout = timeout
+	return &http.Client{Transport: transport}
+}
+
+// AddAttachment uploads the file at path to an issue. The file is streamed from disk
+// as multipart form data, with the header JIRA requires to accept uploads.
+func (client *JiraClient) AddAttachment(ctx context.Context, issueIDOrKey, path string) (*Attachment, error) {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/attachments", issueIDOrKey)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment: %v", err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("failed to read attachment: %s is a directory", path)
+	}
+
+	// the multipart framing around the file is built once, so the file itself can be
+	// streamed from disk with a known length on every attempt
+	var framing bytes.Buffer
+	mw := multipart.NewWriter(&framing)
+	if _, err := mw.CreateFormFile("file", filepath.Base(path)); err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	headLen := framing.Len()
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	head, tail := framing.Bytes()[:headLen], framing.Bytes()[headLen:]
+
+	header := http.Header{}
+	header.Set("X-Atlassian-Token", "no-check")
+
+	body := &requestBody{
+		contentType: mw.FormDataContentType(),
+		header:      header,
+		length:      int64(len(head)) + info.Size() + int64(len(tail)),
+		transfer:    true,
+		open: func() (io.Reader, error) {
+			file, err := os.Open(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read attachment: %v", err)
+			}
+			return struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(head), file, bytes.NewReader(tail)), file}, nil
+		},
+	}
+
+	resp, err := client.makeRequest(ctx, "POST", endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.apiError(resp, "add attachment")
+	}
+
+	// JIRA answers with a list, one entry per uploaded file
+	var attachments []Attachment
+	if err := json.NewDecoder(resp.Body).Decode(&attachments); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+	if len(attachments) == 0 {
+		return nil, fmt.Errorf("failed to add attachment: JIRA returned no attachment")
+	}
+
+	return &attachments[0], nil
+}
+
+// ListAttachments returns the attachments of an issue
+func (client *JiraClient) ListAttachments(ctx context.Context, issueIDOrKey string) ([]Attachment, error) {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s?fields=attachment", issueIDOrKey)
+
+	resp, err := client.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.apiError(resp, "list attachments")
+	}
+
+	var result struct {
+		Fields struct {
+			Attachment []Attachment `json:"attachment"`
+		} `json:"fields"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	return result.Fields.Attachment, nil
+}
+
+// DownloadAttachment streams the content of an attachment to w and returns the number of bytes written
+func (client *JiraClient) DownloadAttachment(ctx context.Context, attachment *Attachment, w io.Writer) (int64, error) {
+	// credentials are only ever sent to the configured instance
+	endpoint, err := client.instancePath(attachment.Content)
+	if err != nil {
+		return 0, fmt.Errorf("attachment %s: %v", attachment.ID, err)
+	}
+
+	header := http.Header{}
+	header.Set("Accept", "*/*")
+	body := &requestBody{
+		contentType: "application/json",
+		header:      header,
+		open:        func() (io.Reader, error) { return nil, nil },
+		transfer:    true,
+	}
+
+	resp, err := client.makeRequest(ctx, "GET", endpoint, body)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, client.apiError(resp, "download attachment")
+	}
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("failed to download attachment %s: %v", attachment.Filename, err)
+	}
+	return n, nil
+}
+
+// instancePath converts an absolute URL on the configured instance into an endpoint for
+// makeRequest. The host is compared case-insensitively and the scheme is ignored, since
+// the request goes to the base URL anyway; a URL on any other host is refused.
+func (client *JiraClient) instancePath(rawURL string) (string, error) {
+	base, err := url.Parse(client.config.BaseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL %s: %v", client.config.BaseURL, err)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL %s: %v", rawURL, err)
+	}
+
+	endpoint, ok := strings.CutPrefix(u.EscapedPath(), base.EscapedPath())
+	if !strings.EqualFold(u.Host, base.Host) || !ok || !strings.HasPrefix(endpoint, "/") {
+		return "", fmt.Errorf("%s is not served by %s", rawURL, client.config.BaseURL)
+	}
+	if u.RawQuery != "" {
+		endpoint += "?" + u.RawQuery
+	}
+	return endpoint, nil
+}
+
+// DeleteAttachment deletes an attachment
+func (client *JiraClient) DeleteAttachment(ctx context.Context, attachmentID string) error {
+	endpoint := fmt.Sprintf("/rest/api/2/attachment/%s", attachmentID)
+
+	resp, err := client.makeRequest(ctx, "DELETE", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return client.apiError(resp, "delete attachment")
+	}
+
+	return nil
+}
+
+// saveAttachment downloads an attachment into dir under its own file name and returns the path.
+// The file is written next to its destination first, so an interrupted download leaves no
+// partial file behind under the real name.
+func (client *JiraClient) saveAttachment(ctx context.Context, attachment *Attachment, dir, name string) (string, error) {
+	path := filepath.Join(dir, name)
+
+	tmp, err := os.CreateTemp(dir, "."+name+".*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := client.DownloadAttachment(ctx, attachment, tmp); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	// CreateTemp makes the file private, downloads get the usual permissions
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return path, nil
+}
+
+// attachmentFileName returns a safe local file name for an attachment. Names are reduced
+// to their last element so an attachment cannot write outside the target directory,
+// and a name already used in taken is prefixed with the attachment ID.
+func attachmentFileName(attachment *Attachment, taken map[string]bool) string {
+	name := filepath.Base(filepath.FromSlash(strings.ReplaceAll(attachment.Filename, `\`, "/")))
+	if name == "." || name == ".." || name == string(filepath.Separator) {
+		name = "attachment-" + attachment.ID
+	}
+	if taken[name] {
+		name = attachment.ID + "-" + name
+	}
+	taken[name] = true
+	return name
+}
+
+// findAttachment looks up an attachment by ID or file name
+func findAttachment(attachments []Attachment, wanted string) *Attachment {
+	for i := range attachments {
+		if attachments[i].ID == wanted || attachments[i].Filename == wanted {
+			return &attachments[i]
+		}
+	}
+	return nil
+}
+
+// attachmentNames returns the file names of attachments
+func attachmentNames(attachments []Attachment) []string {
+	names := make([]string, 0, len(attachments))
+	for _, a := range attachments {
+		names = append(names, a.Filename)
+	}
+	return names
+}
+
+// formatSize formats a byte count for humans, e.g. "1.2 MB"
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// printAttachments prints the attachments of an issue as a table
+func printAttachments(attachments []Attachment) {
+	if len(attachments) == 0 {
+		fmt.Println("No attachments on this issue.")
+		return
+	}
+	for _, a := range attachments {
+		fmt.Printf("%-10s %9s  %-16s %-20s %s\n", a.ID, formatSize(a.Size),
+			a.Created.Local().Format("2006-01-02 15:04"), a.AuthorName(), a.Filename)
+	}
+}
@@ -0,0 +1,463 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Authentication modes selectable with JIRA_AUTH
+const (
+	authBasic  = "basic"
+	authPAT    = "pat"
+	authCookie = "cookie"
+	authOAuth1 = "oauth1"
+)
+
+// validAuthMode reports whether mode is a known JIRA_AUTH value; empty means probe
+func validAuthMode(mode string) bool {
+	switch mode {
+	case "", authBasic, authPAT, authCookie, authOAuth1:
+		return true
+	}
+	return false
+}
+
+// Authenticator adds credentials to the requests sent by makeRequest
+type Authenticator interface {
+	// Name describes the mechanism for display, e.g. "Personal Access Token (Bearer)"
+	Name() string
+	// Authenticate adds the credentials to req
+	Authenticate(ctx context.Context, req *http.Request) error
+}
+
+// sessionAuthenticator is implemented by authenticators holding a session that can
+// expire; makeRequest invalidates it once when JIRA answers 401 and tries again
+type sessionAuthenticator interface {
+	Authenticator
+	Invalidate()
+}
+
+// newAuthenticator creates the authenticator for an explicit mode
+func newAuthenticator(client *JiraClient, mode string) (Authenticator, error) {
+	config := client.config
+	switch mode {
+	case authBasic:
+		return &basicAuth{username: config.Username, token: config.APIToken}, nil
+	case authPAT:
+		return &bearerAuth{token: config.APIToken}, nil
+	case authCookie:
+		return &cookieAuth{client: client}, nil
+	case authOAuth1:
+		return &oauth1Auth{consumerKey: config.OAuthConsumerKey, keyPath: config.OAuthPrivateKey, token: config.OAuthToken}, nil
+	}
+	return nil, fmt.Errorf("unknown authentication mode %q, expected basic, pat, cookie or oauth1", mode)
+}
+
+// authenticator returns the authenticator of the client, probing for one on first
+// use when JIRA_AUTH is not set
+func (client *JiraClient) authenticator(ctx context.Context) (Authenticator, error) {
+	client.authMu.Lock()
+	defer client.authMu.Unlock()
+
+	if client.auth != nil {
+		return client.auth, nil
+	}
+
+	var auth Authenticator
+	var err error
+	if client.config.AuthMode == "" {
+		auth, err = client.probeAuthenticator(ctx)
+	} else {
+		auth, err = newAuthenticator(client, client.config.AuthMode)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	client.auth = auth
+	return auth, nil
+}
+
+// probeAuthenticator tries the token as a PAT and then as an API token against /myself
+// and keeps the first one JIRA accepts. Bearer goes first because failed Basic logins
+// count towards JIRA's CAPTCHA limit. When neither is accepted the PAT is kept, so the
+// actual request reports JIRA's error.
+func (client *JiraClient) probeAuthenticator(ctx context.Context) (Authenticator, error) {
+	candidates := []Authenticator{
+		&bearerAuth{token: client.config.APIToken},
+		&basicAuth{username: client.config.Username, token: client.config.APIToken},
+	}
+
+	for _, auth := range candidates {
+		user, err := client.probe(ctx, auth)
+		if err != nil {
+			return nil, err
+		}
+		if user != nil {
+			if *DEBUGflag {
+				fmt.Printf("Authentication probe: %s accepted\n", auth.Name())
+			}
+			client.currentUser = user
+			return auth, nil
+		}
+	}
+
+	return candidates[0], nil
+}
+
+// probe requests /myself with auth and returns the user when JIRA accepts the credentials
+func (client *JiraClient) probe(ctx context.Context, auth Authenticator) (*User, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.config.BaseURL+"/rest/api/2/myself", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if err := auth.Authenticate(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := client.limiter.wait(ctx); err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+	client.limiter.observe(resp.Header)
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return nil, nil
+	}
+
+	var user User
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+	return &user, nil
+}
+
+// basicAuth sends the username and API token (or password) as HTTP Basic auth
+type basicAuth struct {
+	username string
+	token    string
+}
+
+func (a *basicAuth) Name() string {
+	return "API Token (Basic)"
+}
+
+func (a *basicAuth) Authenticate(ctx context.Context, req *http.Request) error {
+	auth := base64.StdEncoding.EncodeToString([]byte(a.username + ":" + a.token))
+	req.Header.Set("Authorization", "Basic "+auth)
+	return nil
+}
+
+// bearerAuth sends a Personal Access Token as a Bearer token
+type bearerAuth struct {
+	token string
+}
+
+func (a *bearerAuth) Name() string {
+	return "Personal Access Token (Bearer)"
+}
+
+func (a *bearerAuth) Authenticate(ctx context.Context, req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	return nil
+}
+
+// cookieAuth logs in once through /rest/auth/1/session and sends the session cookie
+type cookieAuth struct {
+	client *JiraClient
+
+	mu     sync.Mutex
+	cookie *http.Cookie
+}
+
+func (a *cookieAuth) Name() string {
+	return "Session cookie"
+}
+
+func (a *cookieAuth) Authenticate(ctx context.Context, req *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.cookie == nil {
+		cookie, err := a.login(ctx)
+		if err != nil {
+			return err
+		}
+		a.cookie = cookie
+	}
+
+	req.AddCookie(a.cookie)
+	return nil
+}
+
+// Invalidate drops the session so the next request logs in again
+func (a *cookieAuth) Invalidate() {
+	a.mu.Lock()
+	a.cookie = nil
+	a.mu.Unlock()
+}
+
+// login creates a session with the username and password
+func (a *cookieAuth) login(ctx context.Context) (*http.Cookie, error) {
+	config := a.client.config
+	body, err := json.Marshal(map[string]string{
+		"username": config.Username,
+		"password": config.APIToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.BaseURL+"/rest/auth/1/session", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	if err := a.client.limiter.wait(ctx); err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	resp, err := a.client.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+	a.client.limiter.observe(resp.Header)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, a.client.apiError(resp, "log in")
+	}
+
+	var session struct {
+		Session struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"session"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+	if session.Session.Name == "" {
+		return nil, fmt.Errorf("failed to log in: JIRA returned no session")
+	}
+
+	return &http.Cookie{Name: session.Session.Name, Value: session.Session.Value}, nil
+}
+
+// oauth1Auth signs requests with OAuth 1.0a RSA-SHA1, as used by JIRA Server and
+// Data Center application links
+type oauth1Auth struct {
+	consumerKey string
+	keyPath     string // PEM file with the consumer's RSA private key
+	token       string // access token
+
+	once   sync.Once
+	key    *rsa.PrivateKey
+	keyErr error
+}
+
+func (a *oauth1Auth) Name() string {
+	return "OAuth 1.0a (RSA-SHA1)"
+}
+
+func (a *oauth1Auth) Authenticate(ctx context.Context, req *http.Request) error {
+	a.once.Do(func() {
+		a.key, a.keyErr = loadRSAPrivateKey(a.keyPath)
+	})
+	if a.keyErr != nil {
+		return a.keyErr
+	}
+
+	params := map[string]string{"oauth_token": a.token}
+	header, err := oauth1Header(a.consumerKey, a.key, req.Method, req.URL, params)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", header)
+	return nil
+}
+
+// loadRSAPrivateKey reads a PKCS#1 or PKCS#8 RSA private key from a PEM file
+func loadRSAPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OAuth private key: %v", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("failed to read OAuth private key: %s is not a PEM file", path)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OAuth private key: %v", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse OAuth private key: %s is not an RSA key", path)
+	}
+	return key, nil
+}
+
+// oauth1Header builds a signed OAuth 1.0a Authorization header. params holds the
+// extra oauth_* parameters of the request, such as oauth_token or oauth_verifier.
+func oauth1Header(consumerKey string, key *rsa.PrivateKey, method string, u *url.URL, params map[string]string) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %v", err)
+	}
+
+	oauth := map[string]string{
+		"oauth_consumer_key":     consumerKey,
+		"oauth_nonce":            base64.RawURLEncoding.EncodeToString(nonce),
+		"oauth_signature_method": "RSA-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(time.Now().Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	for k, v := range params {
+		if v != "" {
+			oauth[k] = v
+		}
+	}
+
+	// the signature covers the oauth parameters and the query string, sorted
+	var pairs []string
+	for k, v := range oauth {
+		pairs = append(pairs, oauthEscape(k)+"="+oauthEscape(v))
+	}
+	for k, values := range u.Query() {
+		for _, v := range values {
+			pairs = append(pairs, oauthEscape(k)+"="+oauthEscape(v))
+		}
+	}
+	sort.Strings(pairs)
+
+	base := *u
+	base.RawQuery, base.Fragment = "", ""
+	baseString := strings.ToUpper(method) + "&" + oauthEscape(base.String()) + "&" + oauthEscape(strings.Join(pairs, "&"))
+
+	digest := sha1.Sum([]byte(baseString))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA1, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign request: %v", err)
+	}
+	oauth["oauth_signature"] = base64.StdEncoding.EncodeToString(signature)
+
+	keys := make([]string, 0, len(oauth))
+	for k := range oauth {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, oauthEscape(k), oauthEscape(oauth[k])))
+	}
+	return "OAuth " + strings.Join(parts, ", "), nil
+}
+
+// oauthEscape percent-encodes s as RFC 5849 requires, leaving only unreserved characters
+func oauthEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// oauth1Authorize runs the OAuth 1.0a dance against JIRA and returns the access token:
+// it fetches a request token, lets the user approve it in the browser and exchanges
+// the verification code for an access token
+func oauth1Authorize(ctx context.Context, config *Config, scanner *bufio.Scanner) (string, error) {
+	key, err := loadRSAPrivateKey(config.OAuthPrivateKey)
+	if err != nil {
+		return "", err
+	}
+	httpClient := &http.Client{Timeout: config.RequestTimeout}
+
+	requestToken, err := oauth1TokenRequest(ctx, httpClient, config, key, "request-token", map[string]string{"oauth_callback": "oob"})
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Println("Open this URL in your browser and allow access:")
+	fmt.Printf("  %s/plugins/servlet/oauth/authorize?oauth_token=%s\n", config.BaseURL, url.QueryEscape(requestToken))
+	fmt.Print("Verification code: ")
+	scanner.Scan()
+	verifier := strings.TrimSpace(scanner.Text())
+	if verifier == "" {
+		return "", fmt.Errorf("no verification code given")
+	}
+
+	return oauth1TokenRequest(ctx, httpClient, config, key, "access-token", map[string]string{
+		"oauth_token":    requestToken,
+		"oauth_verifier": verifier,
+	})
+}
+
+// oauth1TokenRequest calls one of JIRA's OAuth token endpoints and returns the oauth_token it issues
+func oauth1TokenRequest(ctx context.Context, httpClient *http.Client, config *Config, key *rsa.PrivateKey, endpoint string, params map[string]string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.BaseURL+"/plugins/servlet/oauth/"+endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %v", err)
+	}
+	header, err := oauth1Header(config.OAuthConsumerKey, key, req.Method, req.URL, params)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", header)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get OAuth %s: %s (status %d)", endpoint, strings.TrimSpace(string(body)), resp.StatusCode)
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil || values.Get("oauth_token") == "" {
+		return "", fmt.Errorf("failed to get OAuth %s: unexpected response %q", endpoint, string(body))
+	}
+	return values.Get("oauth_token"), nil
+}
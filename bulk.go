@@ -0,0 +1,479 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// bulkChunkSize is the maximum number of issues JIRA accepts in one bulk create request
+const bulkChunkSize = 50
+
+// bulkColumns maps normalized CSV header names onto the column they represent
+var bulkColumns = map[string]string{
+	"project":            "project",
+	"projectkey":         "project",
+	"issuetype":          "issuetype",
+	"type":               "issuetype",
+	"summary":            "summary",
+	"description":        "description",
+	"acceptancecriteria": "acceptance criteria",
+	"storypoints":        "story points",
+	"points":             "story points",
+	"assignee":           "assignee",
+	"priority":           "priority",
+	"labels":             "labels",
+}
+
+// BulkCreateResult holds the outcome of creating a single issue through the bulk endpoint
+type BulkCreateResult struct {
+	ID    string
+	Key   string
+	Error string
+}
+
+// bulkCreateResponse represents the response of the bulk create endpoint
+type bulkCreateResponse struct {
+	Issues []CreateIssueResponse `json:"issues"`
+	Errors []struct {
+		Status        int `json:"status"`
+		ElementErrors struct {
+			ErrorMessages []string          `json:"errorMessages"`
+			Errors        map[string]string `json:"errors"`
+		} `json:"elementErrors"`
+		FailedElementNumber int `json:"failedElementNumber"`
+	} `json:"errors"`
+}
+
+// bulkRow is a validated CSV row ready to be sent to JIRA
+type bulkRow struct {
+	Line  int
+	Issue Issue
+}
+
+// Errors recorded for bulk rows that did not get a definite answer from JIRA
+const (
+	bulkNotSent = "not sent"
+	bulkUnknown = "unknown: interrupted while waiting for JIRA, check before re-running"
+)
+
+// CreateIssuesBulk creates issues through /rest/api/2/issue/bulk in chunks of bulkChunkSize.
+// The returned results always line up with the input slice. When a chunk fails, its rows
+// and every later row are marked with the reason and the error is returned as well.
+func (client *JiraClient) CreateIssuesBulk(ctx context.Context, issues []Issue) ([]BulkCreateResult, error) {
+	results := make([]BulkCreateResult, 0, len(issues))
+
+	notSent := func(err error) ([]BulkCreateResult, error) {
+		for range issues {
+			results = append(results, BulkCreateResult{Error: bulkNotSent})
+		}
+		return results, err
+	}
+
+	// every payload is built and the credentials settled before the first chunk is
+	// sent, so a field that cannot be resolved stops the run before anything is created
+	updates := make([]CreateIssueRequest, 0, len(issues))
+	for _, issue := range issues {
+		fields, err := client.fieldsPayload(ctx, issue.Fields)
+		if err != nil {
+			return notSent(err)
+		}
+		updates = append(updates, CreateIssueRequest{Fields: fields})
+	}
+	if _, err := client.authenticator(ctx); err != nil {
+		return notSent(err)
+	}
+
+	for start := 0; start < len(issues); start += bulkChunkSize {
+		end := start + bulkChunkSize
+		if end > len(issues) {
+			end = len(issues)
+		}
+
+		if err := ctx.Err(); err != nil {
+			for i := start; i < len(issues); i++ {
+				results = append(results, BulkCreateResult{Error: bulkNotSent})
+			}
+			return results, err
+		}
+
+		chunkResults, err := client.createIssuesChunk(ctx, updates[start:end])
+		if err != nil {
+			// a rejected request created nothing; a transport failure or an interruption
+			// may have happened after JIRA received the chunk
+			reason := bulkUnknown
+			var apiErr *APIError
+			if errors.As(err, &apiErr) {
+				reason = "not created: " + err.Error()
+			}
+			for i := start; i < end; i++ {
+				results = append(results, BulkCreateResult{Error: reason})
+			}
+			for i := end; i < len(issues); i++ {
+				results = append(results, BulkCreateResult{Error: bulkNotSent})
+			}
+			return results, err
+		}
+		results = append(results, chunkResults...)
+	}
+
+	return results, nil
+}
+
+// createIssuesChunk sends a single bulk create request and maps the response back onto its input
+func (client *JiraClient) createIssuesChunk(ctx context.Context, updates []CreateIssueRequest) ([]BulkCreateResult, error) {
+	request := map[string]interface{}{
+		"issueUpdates": updates,
+	}
+
+	resp, err := client.makeRequest(ctx, "POST", "/rest/api/2/issue/bulk", request)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// JIRA answers 201 when at least one issue was created and 400 when all of them failed;
+	// both carry the per-element errors
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusBadRequest {
+		return nil, client.apiError(resp, "bulk create issues")
+	}
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+
+	var result bulkCreateResponse
+	if err := json.Unmarshal(bodyBytes, &result); err != nil || (resp.StatusCode == http.StatusBadRequest && len(result.Errors) == 0) {
+		// not a per-element answer, report it like any other failed request
+		resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		return nil, client.apiError(resp, "bulk create issues")
+	}
+
+	results := make([]BulkCreateResult, len(updates))
+	failed := make(map[int]bool)
+	fieldNames := client.knownFieldNames()
+	for _, e := range result.Errors {
+		if e.FailedElementNumber < 0 || e.FailedElementNumber >= len(updates) {
+			continue
+		}
+		failed[e.FailedElementNumber] = true
+		elementErr := &APIError{
+			ErrorMessages: e.ElementErrors.ErrorMessages,
+			Errors:        e.ElementErrors.Errors,
+			fieldNames:    fieldNames,
+		}
+		results[e.FailedElementNumber].Error = strings.Join(elementErr.Messages(), "; ")
+	}
+
+	// created issues are listed in request order, skipping the failed elements
+	created := result.Issues
+	for i := range results {
+		if failed[i] {
+			continue
+		}
+		if len(created) == 0 {
+			results[i].Error = "no result returned by JIRA"
+			continue
+		}
+		results[i].ID = created[0].ID
+		results[i].Key = created[0].Key
+		created = created[1:]
+	}
+
+	return results, nil
+}
+
+// normalizeColumn lowercases a header and strips separators so "Story Points" matches "story_points"
+func normalizeColumn(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(name)
+}
+
+// parseBulkCSV reads and validates every row of a bulk create CSV.
+// Columns other than the built-in ones are resolved as field display names through lookup,
+// and fieldID resolves the custom fields behind the story points and acceptance criteria columns.
+// Nothing is returned unless the whole file is valid, so no issue is created from a broken file.
+func parseBulkCSV(r io.Reader, lookup func(nameOrID string) (*Field, error), fieldID func(column string) (string, error)) ([]bulkRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %v", err)
+	}
+
+	columns := make([]string, len(header))
+	customFields := make(map[int]*Field)
+	seen := make(map[string]bool)
+	var problems []string
+	for i, name := range header {
+		column, ok := bulkColumns[normalizeColumn(name)]
+		if !ok {
+			field, err := lookup(strings.TrimSpace(name))
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("line 1: column %q: %v", name, err))
+				continue
+			}
+			customFields[i] = field
+			continue
+		}
+		if seen[column] {
+			problems = append(problems, fmt.Sprintf("line 1: duplicate column %q", name))
+		}
+		seen[column] = true
+		columns[i] = column
+	}
+	for _, required := range []string{"project", "issuetype", "summary"} {
+		if !seen[required] {
+			problems = append(problems, fmt.Sprintf("line 1: missing required column %q", required))
+		}
+	}
+	customIDs := make(map[string]string)
+	for _, column := range []string{"story points", "acceptance criteria"} {
+		if !seen[column] {
+			continue
+		}
+		id, err := fieldID(column)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("line 1: column %q: %v", column, err))
+			continue
+		}
+		customIDs[column] = id
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid CSV:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	var rows []bulkRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %v", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		values := make(map[string]string)
+		for i, value := range record {
+			if columns[i] != "" {
+				values[columns[i]] = strings.TrimSpace(value)
+			}
+		}
+
+		issue, rowProblems := bulkIssueFromValues(values, customIDs)
+		for i, value := range record {
+			field, ok := customFields[i]
+			value = strings.TrimSpace(value)
+			if !ok || value == "" {
+				continue
+			}
+			v, err := fieldValue(field.Schema, value)
+			if err != nil {
+				rowProblems = append(rowProblems, fmt.Sprintf("%s: %v", field.Name, err))
+				continue
+			}
+			issue.Fields.Custom[field.ID] = v
+		}
+		for _, problem := range rowProblems {
+			problems = append(problems, fmt.Sprintf("line %d: %s", line, problem))
+		}
+		rows = append(rows, bulkRow{Line: line, Issue: issue})
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid CSV:\n  %s", strings.Join(problems, "\n  "))
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("CSV contains no issues")
+	}
+
+	return rows, nil
+}
+
+// bulkIssueFromValues maps the columns of one CSV row onto an Issue and reports every problem found.
+// Story points and acceptance criteria are set under the field IDs in customIDs, keyed by column.
+func bulkIssueFromValues(values map[string]string, customIDs map[string]string) (Issue, []string) {
+	var problems []string
+
+	for _, required := range []string{"project", "issuetype", "summary"} {
+		if values[required] == "" {
+			problems = append(problems, fmt.Sprintf("%s is required", required))
+		}
+	}
+
+	issue := Issue{
+		Fields: IssueFields{
+			Project: &Project{
+				Key: values["project"],
+			},
+			IssueType: &IssueType{
+				Name: values["issuetype"],
+			},
+			Summary:     values["summary"],
+			Description: values["description"],
+			Custom:      make(map[string]interface{}),
+		},
+	}
+
+	if ac := values["acceptance criteria"]; ac != "" {
+		issue.Fields.Custom[customIDs["acceptance criteria"]] = ac
+	}
+	if sp := values["story points"]; sp != "" {
+		points, err := strconv.ParseFloat(sp, 32)
+		if err != nil || points < 0 {
+			problems = append(problems, fmt.Sprintf("invalid story points %q", sp))
+		} else {
+			issue.Fields.Custom[customIDs["story points"]] = float32(points)
+		}
+	}
+	if assignee := values["assignee"]; assignee != "" {
+		issue.Fields.Assignee = &Assignee{Name: assignee}
+	}
+	if priority := values["priority"]; priority != "" {
+		issue.Fields.Priority = &Priority{Name: priority}
+	}
+	if labels := values["labels"]; labels != "" {
+		// JIRA labels cannot contain spaces, so both commas and whitespace separate them
+		issue.Fields.Labels = strings.FieldsFunc(labels, func(r rune) bool {
+			return r == ',' || r == ';' || r == ' ' || r == '\t'
+		})
+	}
+
+	return issue, problems
+}
+
+// writeBulkResults writes one result line per CSV row with the created key or the error
+func writeBulkResults(w io.Writer, rows []bulkRow, results []BulkCreateResult) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"line", "summary", "key", "error"}); err != nil {
+		return err
+	}
+
+	for i, row := range rows {
+		result := BulkCreateResult{Error: bulkNotSent}
+		if i < len(results) {
+			result = results[i]
+		}
+		record := []string{strconv.Itoa(row.Line), row.Issue.Fields.Summary, result.Key, result.Error}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// bulkSummary counts the outcome of a bulk creation
+type bulkSummary struct {
+	Total   int
+	Created int
+	Failed  int // rejected by JIRA or in an unknown state
+	NotSent int
+	Written bool // whether the result file was written
+
+	// WatchFailed counts created issues some auto watchers could not be added to
+	WatchFailed int
+}
+
+// bulkCreateFromFile validates a CSV file, creates its issues and writes the result CSV.
+// The summary is returned even when creation stops early, so callers can report progress.
+func bulkCreateFromFile(ctx context.Context, client *JiraClient, path, resultPath string) (*bulkSummary, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CSV file: %v", err)
+	}
+	defer file.Close()
+
+	customFields := map[string]string{
+		"story points":        client.config.StoryPointsField,
+		"acceptance criteria": client.config.AcceptanceCriteriaField,
+	}
+	rows, err := parseBulkCSV(file, func(nameOrID string) (*Field, error) {
+		return client.LookupField(ctx, nameOrID)
+	}, func(column string) (string, error) {
+		return client.FieldID(ctx, customFields[column])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the result file is created before anything is sent, so a bad path fails
+	// while no issue exists yet
+	out, err := os.Create(resultPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create result file: %v", err)
+	}
+
+	issues := make([]Issue, 0, len(rows))
+	for _, row := range rows {
+		issues = append(issues, row.Issue)
+	}
+
+	results, createErr := client.CreateIssuesBulk(ctx, issues)
+
+	summary := &bulkSummary{Total: len(rows)}
+	for _, result := range results {
+		switch {
+		case result.Key != "":
+			summary.Created++
+		case result.Error == bulkNotSent:
+			summary.NotSent++
+		default:
+			summary.Failed++
+		}
+	}
+
+	// auto watchers are added as for a single issue, a failure goes to the error
+	// column of the issue, which exists regardless
+	for i, result := range results {
+		if result.Key == "" || rows[i].Issue.Fields.IssueType == nil {
+			continue
+		}
+		errs := client.addAutoWatchers(ctx, result.Key, rows[i].Issue.Fields.IssueType.Name)
+		if len(errs) == 0 {
+			continue
+		}
+		messages := make([]string, len(errs))
+		for j, err := range errs {
+			messages[j] = err.Error()
+		}
+		results[i].Error = strings.Join(messages, "; ")
+		summary.WatchFailed++
+	}
+
+	writeErr := writeBulkResults(out, rows, results)
+	if err := out.Close(); writeErr == nil {
+		writeErr = err
+	}
+	if writeErr != nil {
+		// the issues exist regardless, list them so they are not lost with the file
+		for i, result := range results {
+			if result.Key == "" {
+				continue
+			}
+			if result.Error != "" {
+				fmt.Printf("line %d: created %s, %s\n", rows[i].Line, result.Key, result.Error)
+			} else {
+				fmt.Printf("line %d: created %s\n", rows[i].Line, result.Key)
+			}
+		}
+		return summary, fmt.Errorf("failed to write result file: %v", writeErr)
+	}
+	summary.Written = true
+
+	return summary, createErr
+}
+
+// bulkResultPath derives the default result file name from the input CSV name
+func bulkResultPath(path string) string {
+	return strings.TrimSuffix(path, ".csv") + "-result.csv"
+}
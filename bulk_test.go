@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testBulkFields is the field list the CSV tests resolve extra columns against
+var testBulkFields = []Field{
+	{ID: "customfield_10010", Name: "Team", Custom: true, Schema: FieldSchema{Type: "option"}},
+	{ID: "customfield_10011", Name: "Effort", Custom: true, Schema: FieldSchema{Type: "number"}},
+}
+
+func testBulkLookup(nameOrID string) (*Field, error) {
+	for i := range testBulkFields {
+		f := &testBulkFields[i]
+		if strings.EqualFold(f.Name, nameOrID) || f.ID == nameOrID {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown field %q", nameOrID)
+}
+
+func testBulkFieldID(column string) (string, error) {
+	switch column {
+	case "story points":
+		return "customfield_10002", nil
+	case "acceptance criteria":
+		return "customfield_10003", nil
+	}
+	return "", fmt.Errorf("no field for column %q", column)
+}
+
+func TestParseBulkCSV(t *testing.T) {
+	input := `Project, Issue Type, Summary, Story Points, Acceptance Criteria, Labels, Team, Effort
+GTJ, Story, Add login page, 3, Users can log in, "frontend, auth", Web, 2.5
+GTJ, Bug, Fix crash, 0, , , ,
+`
+	rows, err := parseBulkCSV(strings.NewReader(input), testBulkLookup, testBulkFieldID)
+	if err != nil {
+		t.Fatalf("parseBulkCSV failed: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+
+	first := rows[0]
+	if first.Line != 2 {
+		t.Errorf("first row is on line %d, want 2", first.Line)
+	}
+	fields := first.Issue.Fields
+	if fields.Project.Key != "GTJ" || fields.IssueType.Name != "Story" || fields.Summary != "Add login page" {
+		t.Errorf("first row is %s/%s %q", fields.Project.Key, fields.IssueType.Name, fields.Summary)
+	}
+	if want := []string{"frontend", "auth"}; !reflect.DeepEqual(fields.Labels, want) {
+		t.Errorf("labels = %q, want %q", fields.Labels, want)
+	}
+	wantCustom := map[string]interface{}{
+		"customfield_10002": float32(3),
+		"customfield_10003": "Users can log in",
+		"customfield_10010": map[string]string{"value": "Web"},
+		"customfield_10011": 2.5,
+	}
+	if !reflect.DeepEqual(fields.Custom, wantCustom) {
+		t.Errorf("custom fields = %v, want %v", fields.Custom, wantCustom)
+	}
+
+	// an explicit 0 is sent, empty cells are left out
+	wantCustom = map[string]interface{}{"customfield_10002": float32(0)}
+	if !reflect.DeepEqual(rows[1].Issue.Fields.Custom, wantCustom) {
+		t.Errorf("custom fields of the second row = %v, want %v", rows[1].Issue.Fields.Custom, wantCustom)
+	}
+}
+
+func TestParseBulkCSVErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		problems []string // every one must appear in the error
+	}{
+		{
+			name:     "missing required columns",
+			input:    "Project,Description\nGTJ,x\n",
+			problems: []string{`line 1: missing required column "issuetype"`, `line 1: missing required column "summary"`},
+		},
+		{
+			name:     "duplicate column",
+			input:    "Project,Type,Issue Type,Summary\nGTJ,Bug,Bug,x\n",
+			problems: []string{`line 1: duplicate column "Issue Type"`},
+		},
+		{
+			name:     "unknown column",
+			input:    "Project,Type,Summary,Colour\nGTJ,Bug,x,red\n",
+			problems: []string{`line 1: column "Colour": unknown field "Colour"`},
+		},
+		{
+			name:     "empty required values",
+			input:    "Project,Type,Summary\nGTJ,,x\n,Bug,\n",
+			problems: []string{"line 2: issuetype is required", "line 3: project is required", "line 3: summary is required"},
+		},
+		{
+			name:     "invalid story points",
+			input:    "Project,Type,Summary,Points\nGTJ,Bug,x,many\nGTJ,Bug,y,-1\n",
+			problems: []string{`line 2: invalid story points "many"`, `line 3: invalid story points "-1"`},
+		},
+		{
+			name:     "invalid custom field value",
+			input:    "Project,Type,Summary,Effort\nGTJ,Bug,x,lots\n",
+			problems: []string{`line 2: Effort: invalid number "lots"`},
+		},
+		{
+			name:     "quoted line break keeps line numbers",
+			input:    "Project,Type,Summary,Description\nGTJ,Bug,x,\"two\nlines\"\nGTJ,,y,z\n",
+			problems: []string{"line 4: issuetype is required"},
+		},
+		{
+			name:     "no issues",
+			input:    "Project,Type,Summary\n",
+			problems: []string{"CSV contains no issues"},
+		},
+		{
+			name:     "empty file",
+			input:    "",
+			problems: []string{"failed to read CSV header"},
+		},
+		{
+			name:     "ragged row",
+			input:    "Project,Type,Summary\nGTJ,Bug\n",
+			problems: []string{"failed to read CSV"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseBulkCSV(strings.NewReader(tt.input), testBulkLookup, testBulkFieldID)
+			if err == nil {
+				t.Fatalf("parseBulkCSV returned %d rows, want an error", len(rows))
+			}
+			if rows != nil {
+				t.Errorf("parseBulkCSV returned rows along with the error")
+			}
+			for _, problem := range tt.problems {
+				if !strings.Contains(err.Error(), problem) {
+					t.Errorf("error %q does not mention %q", err, problem)
+				}
+			}
+		})
+	}
+}
+
+func TestParseBulkCSVUnresolvedCustomColumn(t *testing.T) {
+	input := "Project,Type,Summary,Story Points\nGTJ,Bug,x,1\n"
+	fieldID := func(column string) (string, error) {
+		return "", fmt.Errorf("no field named %q", "Story Points")
+	}
+
+	_, err := parseBulkCSV(strings.NewReader(input), testBulkLookup, fieldID)
+	if err == nil || !strings.Contains(err.Error(), `line 1: column "story points": no field named "Story Points"`) {
+		t.Errorf("got error %v, want the unresolved story points column", err)
+	}
+}
+
+func TestBulkIssueFromValues(t *testing.T) {
+	customIDs := map[string]string{"story points": "customfield_10002", "acceptance criteria": "customfield_10003"}
+
+	tests := []struct {
+		name     string
+		values   map[string]string
+		check    func(t *testing.T, fields IssueFields)
+		problems []string
+	}{
+		{
+			name:   "all columns",
+			values: map[string]string{"project": "GTJ", "issuetype": "Task", "summary": "S", "description": "D", "assignee": "jdoe", "priority": "High", "labels": "a;b c\td"},
+			check: func(t *testing.T, fields IssueFields) {
+				if fields.Description != "D" || fields.Assignee.Name != "jdoe" || fields.Priority.Name != "High" {
+					t.Errorf("fields = %+v", fields)
+				}
+				if want := []string{"a", "b", "c", "d"}; !reflect.DeepEqual(fields.Labels, want) {
+					t.Errorf("labels = %q, want %q", fields.Labels, want)
+				}
+			},
+		},
+		{
+			name:   "optional columns left empty",
+			values: map[string]string{"project": "GTJ", "issuetype": "Task", "summary": "S"},
+			check: func(t *testing.T, fields IssueFields) {
+				if fields.Assignee != nil || fields.Priority != nil || fields.Labels != nil || len(fields.Custom) != 0 {
+					t.Errorf("fields = %+v, want only the required ones", fields)
+				}
+			},
+		},
+		{
+			name:   "fractional story points",
+			values: map[string]string{"project": "GTJ", "issuetype": "Task", "summary": "S", "story points": "0.5"},
+			check: func(t *testing.T, fields IssueFields) {
+				if got := fields.Custom["customfield_10002"]; got != float32(0.5) {
+					t.Errorf("story points = %v, want 0.5", got)
+				}
+			},
+		},
+		{
+			name:     "every problem is reported",
+			values:   map[string]string{"story points": "x"},
+			problems: []string{"project is required", "issuetype is required", "summary is required", `invalid story points "x"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue, problems := bulkIssueFromValues(tt.values, customIDs)
+			if !reflect.DeepEqual(problems, tt.problems) {
+				t.Errorf("problems = %q, want %q", problems, tt.problems)
+			}
+			if tt.check != nil {
+				tt.check(t, issue.Fields)
+			}
+		})
+	}
+}
+
+func TestWriteBulkResults(t *testing.T) {
+	rows := []bulkRow{
+		{Line: 2, Issue: Issue{Fields: IssueFields{Summary: "created"}}},
+		{Line: 3, Issue: Issue{Fields: IssueFields{Summary: "rejected, with a comma"}}},
+		{Line: 5, Issue: Issue{Fields: IssueFields{Summary: "never sent"}}},
+	}
+	results := []BulkCreateResult{
+		{Key: "GTJ-1"},
+		{Error: "Summary: too long"},
+	}
+
+	var b bytes.Buffer
+	if err := writeBulkResults(&b, rows, results); err != nil {
+		t.Fatalf("writeBulkResults failed: %v", err)
+	}
+
+	want := `line,summary,key,error
+2,created,GTJ-1,
+3,"rejected, with a comma",,Summary: too long
+5,never sent,,` + bulkNotSent + "\n"
+	if b.String() != want {
+		t.Errorf("result CSV is\n%s\nwant\n%s", b.String(), want)
+	}
+}
@@ -0,0 +1,1614 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Exit codes used by the non-interactive subcommands
+const (
+	exitOK          = 0
+	exitError       = 1
+	exitUsage       = 2
+	exitNotFound    = 3
+	exitInterrupted = 130
+)
+
+// usageError marks an error caused by bad command-line input rather than by JIRA
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+// usagef builds a usageError with a formatted message
+func usagef(format string, args ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// interruptedError is returned by a command working through a list of items when it
+// is interrupted, so runCommand can report what was and was not done
+type interruptedError struct {
+	err     error
+	done    []string
+	current string // in flight when interrupted, JIRA may or may not have applied it
+	notDone []string
+}
+
+func (e *interruptedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *interruptedError) Unwrap() error {
+	return e.err
+}
+
+// report prints the items that were done, the one in flight and the ones left
+func (e *interruptedError) report(w io.Writer) {
+	if len(e.done) > 0 {
+		fmt.Fprintf(w, "Done: %s\n", strings.Join(e.done, ", "))
+	}
+	if e.current != "" {
+		fmt.Fprintf(w, "Unconfirmed, check before re-running: %s\n", e.current)
+	}
+	if len(e.notDone) > 0 {
+		fmt.Fprintf(w, "Not done: %s\n", strings.Join(e.notDone, ", "))
+	}
+}
+
+// command describes a top-level subcommand such as `jeera issue`
+type command struct {
+	name     string
+	synopsis string
+	summary  string
+	run      func(ctx context.Context, client *JiraClient, args []string) error
+
+	// local commands run without a JIRA connection and receive a nil client
+	local bool
+}
+
+// commands returns the table of available subcommands
+func commands() []command {
+	return []command{
+		{"issue", "issue get <key> [-o format] [--watch|--unwatch] | create --project P --type T --summary S [--watch|--unwatch] | edit <key> [--summary S] [--clear field] [--unassign] [--watch|--unwatch] | watchers <key> [--add user] [--rm user]", "get, create or edit an issue, or manage its watchers", runIssueCommand, false},
+		{"watching", "watching [-o format]", "list the issues you watch", runWatchingCommand, false},
+		{"transition", "transition <key> <name|id> [--resolution name] [--field Name=value]... [--comment text|-]", "move an issue through a transition", runTransitionCommand, false},
+		{"label", "label add|rm <key> <label>... | add|rm --jql '<jql>' <label>...", "add or remove labels on an issue or every issue matching a query", runLabelCommand, false},
+		{"move", "move <key> <status> [--resolution name] [--field Name=value]... [--dry-run]", "move an issue to a status, through several transitions if needed", runMoveCommand, false},
+		{"transitions", "transitions <key> [-o format]", "list the transitions available on an issue", runTransitionsCommand, false},
+		{"comments", "comments <key> [-o format]", "list the comments of an issue", runCommentsCommand, false},
+		{"comment", "comment add <key> | edit <key> <id> | rm <key> <id> [--body text|-]", "add, edit or delete a comment", runCommentCommand, false},
+		{"log", "log <key> <duration> [comment] [--started YYYY-MM-DD[ HH:MM]]", "log time spent on an issue, e.g. 2h or \"1h 30m\"", runLogCommand, false},
+		{"worklogs", "worklogs <key> [-o format]", "list the work logged on an issue", runWorklogsCommand, false},
+		{"worklog", "worklog edit <key> <id> [--time d] [--started t] [--comment text] | rm <key> <id>", "edit or delete a worklog", runWorklogCommand, false},
+		{"timesheet", "timesheet [--week 2026-W42] [--user name|me] [-o format]", "show the hours a user logged per issue and day of a week", runTimesheetCommand, false},
+		{"attach", "attach <key> <file>...", "upload files as attachments to an issue", runAttachCommand, false},
+		{"attachments", "attachments list <key> [-o format] | get <key> [<id|name>...] [--all] [--dir d] | rm <id>...", "list, download or delete attachments", runAttachmentsCommand, false},
+		{"link", "link list <key> [-o format] | add <from> <relation> <to> | rm <link-id> | types [-o format]", "list, create or remove issue links", runLinkCommand, false},
+		{"fields", "fields [filter] [--issue key] [--refresh] [-o format]", "list fields with their IDs, or the editable fields of an issue", runFieldsCommand, false},
+		{"whoami", "whoami [-o format]", "show the user owning the configured credentials", runWhoamiCommand, false},
+		{"search", "search '<jql>' [-o format]", "list every issue matching a JQL query", runSearchCommand, false},
+		{"bulk", "bulk create --file issues.csv [--out result.csv]", "create issues in bulk from a CSV file", runBulkCommand, false},
+		{"sprint", "sprint mine [--user name] [-o format]", "show the active sprint issues of a user by status", runSprintCommand, false},
+		{"config", "config list | use <profile> | show [profile] [-o format]", "list, select or show configuration profiles", runConfigCommand, true},
+		{"token", "token set [--api-token] | rm | list", "keep the token of the active instance in the encrypted token store", runTokenCommand, true},
+		{"oauth1", "oauth1 login", "authorize jeera through an OAuth 1.0a application link", runOAuth1Command, true},
+	}
+}
+
+// printUsage prints the global usage text including all subcommands
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: jeera [flags] [command] [args]\n\n")
+	fmt.Fprintf(w, "Without a command jeera starts the interactive menu.\n\n")
+	fmt.Fprintf(w, "Commands:\n")
+	for _, cmd := range commands() {
+		fmt.Fprintf(w, "  %-12s %s\n", cmd.name, cmd.summary)
+		fmt.Fprintf(w, "               jeera %s\n", cmd.synopsis)
+	}
+	fmt.Fprintf(w, "\nFlags:\n")
+	flag.CommandLine.SetOutput(w)
+	flag.PrintDefaults()
+}
+
+// runCommand dispatches a non-interactive subcommand and returns the process exit code
+func runCommand(ctx context.Context, args []string) int {
+	for _, cmd := range commands() {
+		if cmd.name != args[0] {
+			continue
+		}
+
+		var client *JiraClient
+		if !cmd.local {
+			if _, client = connect(); client == nil {
+				return exitError
+			}
+		}
+
+		err := cmd.run(ctx, client, args[1:])
+		if err == nil {
+			return exitOK
+		}
+
+		var uerr *usageError
+		if errors.As(err, &uerr) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Usage: jeera %s\n", cmd.synopsis)
+			return exitUsage
+		}
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		var ierr *interruptedError
+		interrupted := errors.As(err, &ierr)
+		if ctx.Err() != nil || interrupted {
+			fmt.Fprintf(os.Stderr, "Interrupted: %v\n", err)
+			if interrupted {
+				ierr.report(os.Stderr)
+			}
+			return exitInterrupted
+		}
+		printError(client, err)
+		if IsNotFound(err) {
+			return exitNotFound
+		}
+		return exitError
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: unknown command %q\n\n", args[0])
+	printUsage(os.Stderr)
+	return exitUsage
+}
+
+// printError prints a command failure to stderr, listing JIRA's messages one per line
+func printError(client *JiraClient, err error) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || len(apiErr.Messages()) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return
+	}
+
+	// field errors are keyed by ID; show display names where the field list is
+	// already known, as a failed command should not make more requests
+	if len(apiErr.Errors) > 0 && client != nil {
+		apiErr.fieldNames = client.knownFieldNames()
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: failed to %s (status %d, %s %s)\n", apiErr.Op, apiErr.StatusCode, apiErr.Method, apiErr.Endpoint)
+	for _, message := range apiErr.Messages() {
+		fmt.Fprintf(os.Stderr, "  %s\n", message)
+	}
+}
+
+// parseArgs parses flags that may be interspersed with positional arguments,
+// so that both `issue get KEY -flag` and `issue get -flag KEY` work
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, usagef("%v", err)
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// stringList is a repeatable string flag
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ", ")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// newFlagSet creates a flag set for a subcommand that reports errors instead of exiting
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+func runIssueCommand(ctx context.Context, client *JiraClient, args []string) error {
+	if len(args) == 0 {
+		return usagef("missing issue subcommand")
+	}
+
+	switch args[0] {
+	case "get":
+		return runIssueGet(ctx, client, args[1:])
+	case "create":
+		return runIssueCreate(ctx, client, args[1:])
+	case "edit":
+		return runIssueEdit(ctx, client, args[1:])
+	case "watchers":
+		return runIssueWatchers(ctx, client, args[1:])
+	default:
+		return usagef("unknown issue subcommand %q", args[0])
+	}
+}
+
+func runIssueGet(ctx context.Context, client *JiraClient, args []string) error {
+	fs := newFlagSet("issue get")
+	output := addOutputFlag(fs)
+	watch := addWatchFlags(fs)
+	var extraFields stringList
+	fs.Var(&extraFields, "field", "additional field to print, by display name or ID (repeatable)")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("issue get expects exactly one issue key")
+	}
+	if err := watch.apply(ctx, client, positional[0]); err != nil {
+		return err
+	}
+
+	issue, err := client.GetIssue(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	// resolve the extra fields before printing anything
+	extra := make([]*Field, 0, len(extraFields))
+	for _, name := range extraFields {
+		field, err := client.LookupField(ctx, name)
+		if err != nil {
+			return err
+		}
+		extra = append(extra, field)
+	}
+
+	r := issueRecords([]Issue{*issue}, func() {
+		printIssue(issue)
+		for _, field := range extra {
+			fmt.Printf("%s: %s\n", field.Name, formatFieldValue(issue.Fields.Raw[field.ID]))
+		}
+	})
+	r.single = true
+	for _, field := range extra {
+		r.columns = append(r.columns, field.Name)
+		r.rows[0] = append(r.rows[0], formatFieldValue(issue.Fields.Raw[field.ID]))
+	}
+	return output.print(r)
+}
+
+func runIssueCreate(ctx context.Context, client *JiraClient, args []string) error {
+	fs := newFlagSet("issue create")
+	project := fs.String("project", "", "project key (required)")
+	issueType := fs.String("type", "", "issue type, e.g. Bug, Task, Story (required)")
+	summary := fs.String("summary", "", "issue summary (required)")
+	description := fs.String("description", "", "issue description")
+	acceptanceCriteria := fs.String("acceptance", "", "acceptance criteria")
+	storyPoints := fs.String("points", "", "story points")
+	priority := fs.String("priority", "", "priority name")
+	assignee := fs.String("assignee", "", "assignee username, or \"me\"")
+	watch := addWatchFlags(fs)
+	var labels, components, fixVersions, versions stringList
+	fs.Var(&labels, "label", "label to add (repeatable)")
+	fs.Var(&components, "component", "component name (repeatable)")
+	fs.Var(&fixVersions, "fix-version", "fix version name (repeatable)")
+	fs.Var(&versions, "affects-version", "affects version name (repeatable)")
+	var extraFields stringList
+	fs.Var(&extraFields, "field", "additional field as \"Display Name=value\" (repeatable)")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("issue create takes no positional arguments")
+	}
+	if *project == "" || *issueType == "" || *summary == "" {
+		return usagef("--project, --type and --summary are required")
+	}
+	if err := watch.check(); err != nil {
+		return err
+	}
+	if err := validateLabels(labels); err != nil {
+		return usagef("%v", err)
+	}
+
+	issue := &Issue{
+		Fields: IssueFields{
+			Project: &Project{
+				Key: *project,
+			},
+			IssueType: &IssueType{
+				Name: *issueType,
+			},
+			Summary:            *summary,
+			Description:        *description,
+			AcceptanceCriteria: *acceptanceCriteria,
+		},
+	}
+	if *storyPoints != "" {
+		sp, err := strconv.ParseFloat(*storyPoints, 32)
+		if err != nil {
+			return usagef("invalid story points value %q", *storyPoints)
+		}
+		points := float32(sp)
+		issue.Fields.StoryPoints = &points
+	}
+	if *priority != "" {
+		issue.Fields.Priority = &Priority{Name: *priority}
+	}
+	issue.Fields.Labels = labels
+	for _, name := range components {
+		issue.Fields.Components = append(issue.Fields.Components, Component{Name: name})
+	}
+	for _, name := range fixVersions {
+		issue.Fields.FixVersions = append(issue.Fields.FixVersions, Version{Name: name})
+	}
+	for _, name := range versions {
+		issue.Fields.Versions = append(issue.Fields.Versions, Version{Name: name})
+	}
+	if *assignee != "" {
+		name, err := client.resolveUsername(ctx, *assignee)
+		if err != nil {
+			return err
+		}
+		issue.Fields.Assignee = &Assignee{Name: name}
+	}
+	if len(extraFields) > 0 {
+		assignments, err := parseFieldAssignments(extraFields)
+		if err != nil {
+			return usagef("%v", err)
+		}
+		issue.Fields.Custom, err = client.resolveCustomFields(ctx, assignments)
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := client.CreateIssue(ctx, issue)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(result.Key)
+	for _, err := range client.addAutoWatchers(ctx, result.Key, *issueType) {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return watch.apply(ctx, client, result.Key)
+}
+
+func runIssueEdit(ctx context.Context, client *JiraClient, args []string) error {
+	fs := newFlagSet("issue edit")
+	summary := fs.String("summary", "", "new summary")
+	description := fs.String("description", "", "new description")
+	acceptanceCriteria := fs.String("acceptance", "", "new acceptance criteria")
+	storyPoints := fs.String("points", "", "new story points")
+	priority := fs.String("priority", "", "new priority name")
+	assignee := fs.String("assignee", "", "new assignee username, or \"me\"")
+	unassign := fs.Bool("unassign", false, "remove the assignee")
+	watch := addWatchFlags(fs)
+	var clear, extraFields stringList
+	fs.Var(&clear, "clear", "field to empty, by display name or ID (repeatable)")
+	fs.Var(&extraFields, "field", "additional field as \"Display Name=value\" (repeatable)")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("issue edit expects exactly one issue key")
+	}
+	if err := watch.check(); err != nil {
+		return err
+	}
+	issueIDOrKey := positional[0]
+
+	update := IssueUpdate{}
+	for _, name := range clear {
+		// the assignee is changed through its own endpoint
+		if strings.EqualFold(name, "assignee") {
+			if *assignee != "" {
+				return usagef("%s cannot be both cleared and set", name)
+			}
+			*unassign = true
+			continue
+		}
+		update.Clear = append(update.Clear, name)
+	}
+	if *unassign && *assignee != "" {
+		return usagef("--assignee and --unassign cannot be combined")
+	}
+	if *summary != "" {
+		update.Summary = summary
+	}
+	if *description != "" {
+		update.Description = description
+	}
+	if *acceptanceCriteria != "" {
+		update.AcceptanceCriteria = acceptanceCriteria
+	}
+	if *storyPoints != "" {
+		sp, err := strconv.ParseFloat(*storyPoints, 32)
+		if err != nil {
+			return usagef("invalid story points value %q", *storyPoints)
+		}
+		points := float32(sp)
+		update.StoryPoints = &points
+	}
+	if *priority != "" {
+		update.Priority = priority
+	}
+	if len(extraFields) > 0 {
+		assignments, err := parseFieldAssignments(extraFields)
+		if err != nil {
+			return usagef("%v", err)
+		}
+		values, err := client.resolveCustomFields(ctx, assignments)
+		if err != nil {
+			return err
+		}
+		update.Operations = make(map[string][]Operation)
+		for id, value := range values {
+			update.Operations[id] = []Operation{{opSet: value}}
+		}
+	}
+	if update.IsZero() && *assignee == "" && !*unassign && !watch.requested() {
+		return usagef("issue edit needs at least one change")
+	}
+	if len(update.Clear) > 0 {
+		name, err := client.clearConflict(ctx, update)
+		if err != nil {
+			return err
+		}
+		if name != "" {
+			return usagef("%s cannot be both cleared and set", name)
+		}
+	}
+
+	var newAssignee *Assignee
+	if *assignee != "" {
+		name, err := client.resolveUsername(ctx, *assignee)
+		if err != nil {
+			return err
+		}
+		newAssignee = &Assignee{Name: name}
+	}
+
+	if err := client.UpdateIssue(ctx, issueIDOrKey, update); err != nil {
+		return err
+	}
+	if newAssignee != nil || *unassign {
+		if err := client.UpdateAssignee(ctx, issueIDOrKey, newAssignee); err != nil {
+			return err
+		}
+	}
+	if err := watch.apply(ctx, client, issueIDOrKey); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s updated\n", issueIDOrKey)
+	return nil
+}
+
+func runIssueWatchers(ctx context.Context, client *JiraClient, args []string) error {
+	fs := newFlagSet("issue watchers")
+	output := addOutputFlag(fs)
+	var add, remove stringList
+	fs.Var(&add, "add", "user name to add as watcher, or \"me\" (repeatable)")
+	fs.Var(&remove, "rm", "user name to remove from the watchers, or \"me\" (repeatable)")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("issue watchers expects exactly one issue key")
+	}
+	issueIDOrKey := positional[0]
+
+	for _, name := range add {
+		username, err := client.resolveUsername(ctx, name)
+		if err != nil {
+			return err
+		}
+		if err := client.AddWatcher(ctx, issueIDOrKey, username); err != nil {
+			return err
+		}
+	}
+	for _, name := range remove {
+		username, err := client.resolveUsername(ctx, name)
+		if err != nil {
+			return err
+		}
+		if err := client.RemoveWatcher(ctx, issueIDOrKey, username); err != nil {
+			return err
+		}
+	}
+
+	watchers, err := client.GetWatchers(ctx, issueIDOrKey)
+	if err != nil {
+		return err
+	}
+
+	r := userRecords(watchers.Watchers, func() { printWatchers(watchers) })
+	return output.print(r)
+}
+
+// watchFlags are the --watch and --unwatch options of the issue commands
+type watchFlags struct {
+	watch, unwatch *bool
+}
+
+// addWatchFlags registers --watch and --unwatch on a subcommand flag set
+func addWatchFlags(fs *flag.FlagSet) watchFlags {
+	return watchFlags{
+		watch:   fs.Bool("watch", false, "start watching the issue"),
+		unwatch: fs.Bool("unwatch", false, "stop watching the issue"),
+	}
+}
+
+// check rejects --watch together with --unwatch
+func (w watchFlags) check() error {
+	if *w.watch && *w.unwatch {
+		return usagef("--watch and --unwatch cannot be combined")
+	}
+	return nil
+}
+
+// requested reports whether --watch or --unwatch was given
+func (w watchFlags) requested() bool {
+	return *w.watch || *w.unwatch
+}
+
+// apply adds or removes the current user as watcher of an issue as requested
+func (w watchFlags) apply(ctx context.Context, client *JiraClient, issueIDOrKey string) error {
+	if err := w.check(); err != nil {
+		return err
+	}
+	if !w.requested() {
+		return nil
+	}
+	return client.setWatching(ctx, issueIDOrKey, *w.watch)
+}
+
+func runWatchingCommand(ctx context.Context, client *JiraClient, args []string) error {
+	fs := newFlagSet("watching")
+	output := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("watching takes no arguments")
+	}
+
+	fields := searchTableFields
+	if output.kind != outputTable {
+		fields = []string{"*all"}
+	}
+
+	issues, err := client.SearchIssues(ctx, watchingJQL, fields, nil)
+	if err != nil {
+		return err
+	}
+
+	return output.print(issueRecords(issues, func() { printIssueTable(issues) }))
+}
+
+func runLabelCommand(ctx context.Context, client *JiraClient, args []string) error {
+	if len(args) == 0 {
+		return usagef("missing label subcommand")
+	}
+
+	fs := newFlagSet("label " + args[0])
+	jql := fs.String("jql", "", "change every issue matching this JQL query instead of a single issue")
+	positional, err := parseArgs(fs, args[1:])
+	if err != nil {
+		return err
+	}
+
+	var keys, labels []string
+	if *jql == "" {
+		if len(positional) < 2 {
+			return usagef("label %s expects an issue key (or --jql) and at least one label", args[0])
+		}
+		keys, labels = positional[:1], positional[1:]
+	} else {
+		if len(positional) < 1 {
+			return usagef("label %s expects at least one label", args[0])
+		}
+		labels = positional
+	}
+	if err := validateLabels(labels); err != nil {
+		return usagef("%v", err)
+	}
+
+	var change ListChange
+	switch args[0] {
+	case "add":
+		change.Add = labels
+	case "rm":
+		change.Remove = labels
+	default:
+		return usagef("unknown label subcommand %q", args[0])
+	}
+
+	if *jql != "" {
+		issues, err := client.SearchIssues(ctx, *jql, []string{"labels"}, nil)
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			keys = append(keys, issue.Key)
+		}
+		if len(keys) == 0 {
+			fmt.Println("No issues match the query")
+			return nil
+		}
+	}
+
+	// keep going on failures so one locked issue does not stop a bulk edit
+	var done, failed []string
+	for i, key := range keys {
+		if err := client.UpdateIssue(ctx, key, IssueUpdate{Labels: change}); err != nil {
+			if ctx.Err() != nil {
+				return &interruptedError{err: err, done: done, current: key, notDone: append(failed, keys[i+1:]...)}
+			}
+			fmt.Fprintf(os.Stderr, "%s: %v\n", key, err)
+			failed = append(failed, key)
+			continue
+		}
+		fmt.Printf("%s: labels updated\n", key)
+		done = append(done, key)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d issue(s) could not be updated", len(failed), len(keys))
+	}
+	return nil
+}
+
+func runTransitionCommand(ctx context.Context, client *JiraClient, args []string) error {
+	fs := newFlagSet("transition")
+	resolution := fs.String("resolution", "", "resolution to set, by name or ID")
+	comment := fs.String("comment", "", `comment to add with the transition, "-" reads stdin`)
+	var extraFields stringList
+	fs.Var(&extraFields, "field", "screen field to set as Name=value (repeatable)")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return usagef("transition expects an issue key and a transition name or ID")
+	}
+	issueIDOrKey, wanted := positional[0], positional[1]
+
+	transitions, err := client.GetTransitions(ctx, issueIDOrKey)
+	if err != nil {
+		return err
+	}
+
+	transition := findTransition(transitions, wanted)
+	if transition == nil {
+		names := make([]string, 0, len(transitions))
+		for _, t := range transitions {
+			names = append(names, fmt.Sprintf("%q", t.Name))
+		}
+		return fmt.Errorf("no transition %q available for %s (available: %s)", wanted, issueIDOrKey, strings.Join(names, ", "))
+	}
+
+	assignments, err := parseFieldAssignments(extraFields)
+	if err != nil {
+		return usagef("%v", err)
+	}
+	fields, err := client.transitionFields(ctx, transition, assignments, *resolution)
+	if err != nil {
+		return err
+	}
+
+	// catch missing screen fields here instead of relying on JIRA's error message
+	missing, err := client.unsetRequiredFields(ctx, issueIDOrKey, transition, fields)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		descriptions := make([]string, 0, len(missing))
+		for _, meta := range missing {
+			descriptions = append(descriptions, describeFieldMeta(meta))
+		}
+		return usagef("transition '%s' requires: %s", transition.Name, strings.Join(descriptions, "; "))
+	}
+
+	body := *comment
+	if body == "-" {
+		if body, err = readBody(body, ""); err != nil {
+			return err
+		}
+	}
+
+	if err := client.DoTransition(ctx, issueIDOrKey, transition.ID, fields, body); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s transitioned to '%s'\n", issueIDOrKey, transition.Name)
+	return nil
+}
+
+func runTransitionsCommand(ctx context.Context, client *JiraClient, args []string) error {
+	fs := newFlagSet("transitions")
+	output := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("transitions expects exactly one issue key")
+	}
+
+	transitions, err := client.GetTransitions(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	return output.print(transitionRecords(transitions, func() { printTransitions(transitions) }))
+}
+
+func runMoveCommand(ctx context.Context, client *JiraClient, args []string) error {
+	fs := newFlagSet("move")
+	resolution := fs.String("resolution", "", "resolution to set on the transition screen that asks for it")
+	dryRun := fs.Bool("dry-run", false, "print the transitions without executing them")
+	var extraFields stringList
+	fs.Var(&extraFields, "field", "screen field to set as Name=value (repeatable)")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return usagef("move expects an issue key and a status name")
+	}
+	issueIDOrKey, target := positional[0], positional[1]
+
+	assignments, err := parseFieldAssignments(extraFields)
+	if err != nil {
+		return usagef("%v", err)
+	}
+	if *resolution != "" {
+		assignments["resolution"] = *resolution
+	}
+
+	issue, err := client.GetIssue(ctx, issueIDOrKey)
+	if err != nil {
+		return err
+	}
+
+	path, err := client.workflowPath(ctx, issue, target)
+	if err != nil {
+		return err
+	}
+	if len(path) == 0 {
+		fmt.Printf("%s is already in '%s'\n", issue.Key, issue.Fields.StatusName())
+		return nil
+	}
+	if err := checkHopFields(path, assignments); err != nil {
+		return usagef("%v", err)
+	}
+
+	if *dryRun {
+		for i, h := range path {
+			fmt.Printf("%d. %s\n", i+1, h)
+		}
+		return nil
+	}
+
+	err = client.moveIssue(ctx, issue.Key, path, assignments, func(step int, h hop) {
+		fmt.Printf("%d/%d %s\n", step, len(path), h)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s moved to '%s'\n", issue.Key, path[len(path)-1].Transition.To.Name)
+	return nil
+}
+
+// findTransition looks up a transition by ID or by case-insensitive name
+func findTransition(transitions []Transition, wanted string) *Transition {
+	for i := range transitions {
+		if transitions[i].ID == wanted || strings.EqualFold(transitions[i].Name, wanted) {
+			return &transitions[i]
+		}
+	}
+	return nil
+}
+
+func runCommentsCommand(ctx context.Context, client *JiraClient, args []string) error {
+	fs := newFlagSet("comments")
+	output := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("comments expects exactly one issue key")
+	}
+
+	comments, err := client.GetComments(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	return output.print(commentRecords(comments, func() { printComments(comments) }))
+}
+
+func runSearchCommand(ctx context.Context, client *JiraClient, args []string) error {
+	fs := newFlagSet("search")
+	output := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("search expects exactly one JQL query")
+	}
+
+	fields := searchTableFields
+	if output.kind != outputTable {
+		// machine readable output gets every field, including custom ones
+		fields = []string{"*all"}
+	}
+
+	issues, err := client.SearchIssues(ctx, positional[0], fields, nil)
+	if err != nil {
+		return err
+	}
+
+	return output.print(issueRecords(issues, func() { printIssueTable(issues) }))
+}
+
+func runSprintCommand(ctx context.Context, client *JiraClient, args []string) error {
+	if len(args) == 0 || args[0] != "mine" {
+		return usagef("missing or unknown sprint subcommand")
+	}
+
+	fs := newFlagSet("sprint mine")
+	user := fs.String("user", "", "username to show the sprint for, or \"me\" (default: current user)")
+	output := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args[1:])
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("sprint mine takes no positional arguments")
+	}
+
+	if *user == "me" {
+		*user = ""
+	}
+
+	fields, err := sprintFields(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	issues, err := client.SearchIssues(ctx, activeSprintJQL(*user), fields, nil)
+	if err != nil {
+		return err
+	}
+
+	return output.print(issueRecords(issues, func() {
+		if len(issues) == 0 {
+			fmt.Println("No issues in the active sprint.")
+			return
+		}
+		printSprintBoard(groupByStatus(issues))
+	}))
+}
+
+func runBulkCommand(ctx context.Context, client *JiraClient, args []string) error {
+	if len(args) == 0 || args[0] != "create" {
+		return usagef("missing or unknown bulk subcommand")
+	}
+
+	fs := newFlagSet("bulk create")
+	file := fs.String("file", "", "CSV file with one issue per row (required)")
+	out := fs.String("out", "", "result CSV file (default: <file>-result.csv)")
+	positional, err := parseArgs(fs, args[1:])
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 || *file == "" {
+		return usagef("bulk create expects --file")
+	}
+	if *out == "" {
+		*out = bulkResultPath(*file)
+	}
+
+	summary, err := bulkCreateFromFile(ctx, client, *file, *out)
+	if summary == nil {
+		return err
+	}
+
+	fmt.Printf("Created %d of %d issue(s), %d failed, %d not sent.", summary.Created, summary.Total, summary.Failed, summary.NotSent)
+	if summary.Written {
+		fmt.Printf(" Results written to %s", *out)
+	}
+	fmt.Println()
+	if summary.WatchFailed > 0 && summary.Written {
+		fmt.Fprintf(os.Stderr, "Warning: auto watchers could not be added to %d issue(s), see the error column of %s\n", summary.WatchFailed, *out)
+	}
+	if err != nil {
+		return err
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d issue(s) could not be created", summary.Failed)
+	}
+	return nil
+}
+
+func runCommentCommand(ctx context.Context, client *JiraClient, args []string) error {
+	if len(args) == 0 {
+		return usagef("missing comment subcommand")
+	}
+
+	fs := newFlagSet("comment " + args[0])
+	body := fs.String("body", "", "comment body; \"-\" reads stdin (default: stdin when piped, else $EDITOR)")
+	positional, err := parseArgs(fs, args[1:])
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "add":
+		if len(positional) != 1 {
+			return usagef("comment add expects an issue key")
+		}
+		text, err := readBody(*body, "")
+		if err != nil {
+			return err
+		}
+		if text == "" {
+			return usagef("empty comment body")
+		}
+
+		comment, err := client.AddComment(ctx, positional[0], text)
+		if err != nil {
+			return err
+		}
+		fmt.Println(comment.ID)
+
+	case "edit":
+		if len(positional) != 2 {
+			return usagef("comment edit expects an issue key and a comment ID")
+		}
+
+		initial := ""
+		if *body == "" && stdinIsTerminal() {
+			current, err := findComment(ctx, client, positional[0], positional[1])
+			if err != nil {
+				return err
+			}
+			initial = current.Body
+		}
+		text, err := readBody(*body, initial)
+		if err != nil {
+			return err
+		}
+		if text == "" {
+			return usagef("empty comment body")
+		}
+
+		if _, err := client.UpdateComment(ctx, positional[0], positional[1], text); err != nil {
+			return err
+		}
+		fmt.Printf("Comment %s updated\n", positional[1])
+
+	case "rm":
+		if len(positional) != 2 {
+			return usagef("comment rm expects an issue key and a comment ID")
+		}
+		if err := client.DeleteComment(ctx, positional[0], positional[1]); err != nil {
+			return err
+		}
+		fmt.Printf("Comment %s deleted\n", positional[1])
+
+	default:
+		return usagef("unknown comment subcommand %q", args[0])
+	}
+
+	return nil
+}
+
+func runLogCommand(ctx context.Context, client *JiraClient, args []string) error {
+	fs := newFlagSet("log")
+	startedFlag := fs.String("started", "", "when the work started (default: now)")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 2 || len(positional) > 3 {
+		return usagef("log expects an issue key, a duration and an optional comment")
+	}
+
+	seconds, err := parseDuration(positional[1])
+	if err != nil {
+		return usagef("%v", err)
+	}
+	started, err := parseStarted(*startedFlag)
+	if err != nil {
+		return usagef("%v", err)
+	}
+	comment := ""
+	if len(positional) == 3 {
+		comment = positional[2]
+	}
+
+	worklog, err := client.AddWorklog(ctx, positional[0], started, seconds, comment)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Logged %s on %s (worklog %s)\n", formatDuration(worklog.TimeSpentSeconds), positional[0], worklog.ID)
+	return nil
+}
+
+func runWorklogsCommand(ctx context.Context, client *JiraClient, args []string) error {
+	fs := newFlagSet("worklogs")
+	output := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("worklogs expects exactly one issue key")
+	}
+
+	worklogs, err := client.GetWorklogs(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	return output.print(worklogRecords(worklogs, func() { printWorklogs(worklogs) }))
+}
+
+func runWorklogCommand(ctx context.Context, client *JiraClient, args []string) error {
+	if len(args) == 0 {
+		return usagef("missing worklog subcommand")
+	}
+
+	fs := newFlagSet("worklog " + args[0])
+	timeSpent := fs.String("time", "", "new duration, e.g. 2h or \"1h 30m\"")
+	startedFlag := fs.String("started", "", "new start, YYYY-MM-DD[ HH:MM]")
+	comment := fs.String("comment", "", "new comment")
+	positional, err := parseArgs(fs, args[1:])
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "edit":
+		if len(positional) != 2 {
+			return usagef("worklog edit expects an issue key and a worklog ID")
+		}
+		if *timeSpent == "" && *startedFlag == "" && *comment == "" {
+			return usagef("nothing to change, give --time, --started or --comment")
+		}
+
+		seconds := 0
+		if *timeSpent != "" {
+			if seconds, err = parseDuration(*timeSpent); err != nil {
+				return usagef("%v", err)
+			}
+		}
+		var started time.Time
+		if *startedFlag != "" {
+			if started, err = parseStarted(*startedFlag); err != nil {
+				return usagef("%v", err)
+			}
+		}
+
+		if _, err := client.UpdateWorklog(ctx, positional[0], positional[1], started, seconds, *comment); err != nil {
+			return err
+		}
+		fmt.Printf("Worklog %s updated\n", positional[1])
+
+	case "rm":
+		if len(positional) != 2 {
+			return usagef("worklog rm expects an issue key and a worklog ID")
+		}
+		if err := client.DeleteWorklog(ctx, positional[0], positional[1]); err != nil {
+			return err
+		}
+		fmt.Printf("Worklog %s deleted\n", positional[1])
+
+	default:
+		return usagef("unknown worklog subcommand %q", args[0])
+	}
+
+	return nil
+}
+
+func runTimesheetCommand(ctx context.Context, client *JiraClient, args []string) error {
+	fs := newFlagSet("timesheet")
+	week := fs.String("week", "", "ISO week, e.g. 2026-W42 (default: the current week)")
+	user := fs.String("user", "me", "user whose worklogs to sum, \"me\" for the current user")
+	output := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("timesheet takes no arguments")
+	}
+
+	monday, err := parseISOWeek(*week)
+	if err != nil {
+		return usagef("%v", err)
+	}
+	username, err := client.resolveUsername(ctx, *user)
+	if err != nil {
+		return err
+	}
+
+	sheet, err := client.GetTimesheet(ctx, username, monday)
+	if err != nil {
+		return err
+	}
+
+	return output.print(timesheetRecords(sheet))
+}
+
+func runAttachCommand(ctx context.Context, client *JiraClient, args []string) error {
+	fs := newFlagSet("attach")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 2 {
+		return usagef("attach expects an issue key and at least one file")
+	}
+
+	paths := positional[1:]
+	for i, path := range paths {
+		attachment, err := client.AddAttachment(ctx, positional[0], path)
+		if err != nil {
+			err = fmt.Errorf("%s: %w", path, err)
+			if ctx.Err() != nil {
+				return &interruptedError{err: err, done: paths[:i], current: path, notDone: paths[i+1:]}
+			}
+			return err
+		}
+		fmt.Printf("Attached %s (%s) as %s\n", attachment.Filename, formatSize(attachment.Size), attachment.ID)
+	}
+	return nil
+}
+
+func runAttachmentsCommand(ctx context.Context, client *JiraClient, args []string) error {
+	if len(args) == 0 {
+		return usagef("missing attachments subcommand")
+	}
+
+	fs := newFlagSet("attachments " + args[0])
+	output := addOutputFlag(fs)
+	all := fs.Bool("all", false, "download every attachment of the issue")
+	dir := fs.String("dir", ".", "directory to download into")
+	positional, err := parseArgs(fs, args[1:])
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		if len(positional) != 1 {
+			return usagef("attachments list expects an issue key")
+		}
+		attachments, err := client.ListAttachments(ctx, positional[0])
+		if err != nil {
+			return err
+		}
+		return output.print(attachmentRecords(attachments, func() { printAttachments(attachments) }))
+
+	case "get":
+		if len(positional) < 1 || (*all == (len(positional) > 1)) {
+			return usagef("attachments get expects an issue key and either attachment IDs or names, or --all")
+		}
+		attachments, err := client.ListAttachments(ctx, positional[0])
+		if err != nil {
+			return err
+		}
+
+		// resolve every requested attachment before downloading anything
+		selected := attachments
+		if !*all {
+			selected = nil
+			for _, wanted := range positional[1:] {
+				attachment := findAttachment(attachments, wanted)
+				if attachment == nil {
+					return fmt.Errorf("no attachment %q on %s", wanted, positional[0])
+				}
+				selected = append(selected, *attachment)
+			}
+		}
+		if len(selected) == 0 {
+			fmt.Printf("%s has no attachments\n", positional[0])
+			return nil
+		}
+
+		if err := os.MkdirAll(*dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create %s: %v", *dir, err)
+		}
+		taken := make(map[string]bool)
+		for i := range selected {
+			path, err := client.saveAttachment(ctx, &selected[i], *dir, attachmentFileName(&selected[i], taken))
+			if err != nil {
+				if ctx.Err() != nil {
+					// downloads are written under a temporary name, so the interrupted one is simply not done
+					return &interruptedError{err: err, done: attachmentNames(selected[:i]), notDone: attachmentNames(selected[i:])}
+				}
+				return err
+			}
+			fmt.Printf("Downloaded %s (%s)\n", path, formatSize(selected[i].Size))
+		}
+
+	case "rm":
+		if len(positional) == 0 {
+			return usagef("attachments rm expects at least one attachment ID")
+		}
+		for i, id := range positional {
+			if err := client.DeleteAttachment(ctx, id); err != nil {
+				if ctx.Err() != nil {
+					return &interruptedError{err: err, done: positional[:i], current: id, notDone: positional[i+1:]}
+				}
+				return err
+			}
+			fmt.Printf("Attachment %s deleted\n", id)
+		}
+
+	default:
+		return usagef("unknown attachments subcommand %q", args[0])
+	}
+
+	return nil
+}
+
+func runLinkCommand(ctx context.Context, client *JiraClient, args []string) error {
+	if len(args) == 0 {
+		return usagef("missing link subcommand")
+	}
+
+	fs := newFlagSet("link " + args[0])
+	var output *outputFormat
+	if args[0] == "list" || args[0] == "types" {
+		output = addOutputFlag(fs)
+	}
+	positional, err := parseArgs(fs, args[1:])
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		if len(positional) != 1 {
+			return usagef("link list expects an issue key")
+		}
+		issue, err := client.GetIssue(ctx, positional[0])
+		if err != nil {
+			return err
+		}
+		links := issue.Fields.IssueLinks
+		return output.print(linkRecords(links, func() { printIssueLinks(links) }))
+
+	case "add":
+		if len(positional) != 3 {
+			return usagef("link add expects <from> <relation> <to>, e.g. GTJ-1 blocks GTJ-2")
+		}
+		if err := linkIssues(ctx, client, positional[0], positional[1], positional[2]); err != nil {
+			return err
+		}
+		fmt.Printf("%s %s %s\n", positional[0], positional[1], positional[2])
+
+	case "rm":
+		if len(positional) != 1 {
+			return usagef("link rm expects a link ID")
+		}
+		if err := client.DeleteIssueLink(ctx, positional[0]); err != nil {
+			return err
+		}
+		fmt.Printf("Link %s deleted\n", positional[0])
+
+	case "types":
+		types, err := client.GetIssueLinkTypes(ctx)
+		if err != nil {
+			return err
+		}
+		return output.print(linkTypeRecords(types, func() {
+			for _, t := range types {
+				fmt.Printf("%s: %q / %q\n", t.Name, t.Outward, t.Inward)
+			}
+		}))
+
+	default:
+		return usagef("unknown link subcommand %q", args[0])
+	}
+
+	return nil
+}
+
+func runFieldsCommand(ctx context.Context, client *JiraClient, args []string) error {
+	fs := newFlagSet("fields")
+	output := addOutputFlag(fs)
+	issueKey := fs.String("issue", "", "list the fields editable on this issue (from editmeta)")
+	refresh := fs.Bool("refresh", false, "ignore the cached field list")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		return usagef("fields accepts at most one filter")
+	}
+	filter := ""
+	if len(positional) == 1 {
+		filter = positional[0]
+	}
+
+	if *issueKey != "" {
+		meta, err := client.GetEditMeta(ctx, *issueKey)
+		if err != nil {
+			return err
+		}
+		return output.print(fieldMetaRecords(filterEditMeta(meta, filter), func() { printEditMeta(meta, filter) }))
+	}
+
+	fields, err := client.fields(ctx, *refresh)
+	if err != nil {
+		return err
+	}
+	return output.print(fieldRecords(filterFields(fields, filter), func() { printFields(fields, filter) }))
+}
+
+func runWhoamiCommand(ctx context.Context, client *JiraClient, args []string) error {
+	fs := newFlagSet("whoami")
+	output := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("whoami takes no arguments")
+	}
+
+	user, err := client.CurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	r := userRecords([]User{*user}, func() {
+		fmt.Printf("Name: %s\n", user.Name)
+		fmt.Printf("Display Name: %s\n", user.DisplayName)
+		fmt.Printf("Email: %s\n", user.EmailAddress)
+		fmt.Printf("Time Zone: %s\n", user.TimeZone)
+	})
+	r.single = true
+	return output.print(r)
+}
+
+func runConfigCommand(ctx context.Context, client *JiraClient, args []string) error {
+	if len(args) == 0 {
+		return usagef("missing config subcommand")
+	}
+
+	fs := newFlagSet("config " + args[0])
+	var output *outputFormat
+	if args[0] == "show" {
+		output = addOutputFlag(fs)
+	}
+	positional, err := parseArgs(fs, args[1:])
+	if err != nil {
+		return err
+	}
+
+	profiles, err := LoadProfiles()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		if len(positional) != 0 {
+			return usagef("config list takes no arguments")
+		}
+		if len(profiles.Profiles) == 0 {
+			fmt.Printf("No profiles defined in %s\n", profiles.path)
+			return nil
+		}
+		active := *profileFlag
+		if active == "" {
+			active = envSetting("JEERA_PROFILE")
+		}
+		if active == "" {
+			active = profiles.Current
+		}
+		printProfiles(os.Stdout, profiles, active)
+		return nil
+
+	case "use":
+		if len(positional) != 1 {
+			return usagef("config use expects exactly one profile name")
+		}
+		name := positional[0]
+		if _, ok := profiles.Profiles[name]; !ok {
+			return fmt.Errorf("profile %q not found in %s", name, profiles.path)
+		}
+		if err := setCurrentProfile(profiles, name); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Now using profile %q\n", name)
+		if env := envSetting("JEERA_PROFILE"); env != "" && env != name {
+			fmt.Printf("Note: JEERA_PROFILE=%s is set and takes precedence\n", env)
+		}
+		return nil
+
+	case "show":
+		if len(positional) > 1 {
+			return usagef("config show takes at most one profile name")
+		}
+		name := *profileFlag
+		if len(positional) == 1 {
+			name = positional[0]
+		}
+		// the token is only described, showing settings should not run
+		// JIRA_PAT_COMMAND or ask for the token store passphrase
+		config, src, err := loadSettings(name)
+		if err != nil {
+			return err
+		}
+		if err := src.describeCredentials(config); err != nil {
+			return err
+		}
+		return output.print(configRecords(config, func() { printConfig(os.Stdout, config) }))
+
+	default:
+		return usagef("unknown config subcommand %q", args[0])
+	}
+}
+
+func runTokenCommand(ctx context.Context, client *JiraClient, args []string) error {
+	if len(args) == 0 {
+		return usagef("missing token subcommand")
+	}
+
+	fs := newFlagSet("token " + args[0])
+	apiToken := fs.Bool("api-token", false, "store an API token for Basic auth instead of a PAT")
+	positional, err := parseArgs(fs, args[1:])
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("token %s takes no arguments", args[0])
+	}
+
+	store, err := loadTokenStore()
+	if err != nil {
+		return err
+	}
+
+	if args[0] == "list" {
+		if len(store.Entries) == 0 {
+			fmt.Printf("No tokens stored in %s\n", store.path)
+			return nil
+		}
+		for _, u := range store.URLs() {
+			fmt.Printf("%s (%s)\n", u, store.Entries[u].Kind)
+		}
+		return nil
+	}
+
+	// set and rm work on the instance of the active profile or environment
+	config, _, err := loadSettings(*profileFlag)
+	if err != nil {
+		return err
+	}
+	if config.BaseURL == "" {
+		return fmt.Errorf("no JIRA_BASE_URL configured, select a profile or set it first")
+	}
+
+	switch args[0] {
+	case "set":
+		token, err := readSecret("Token: ")
+		if err != nil {
+			return err
+		}
+		if token == "" {
+			return usagef("empty token")
+		}
+		passphrase, err := readSecret("New passphrase: ")
+		if err != nil {
+			return err
+		}
+		confirm, err := readSecret("Repeat passphrase: ")
+		if err != nil {
+			return err
+		}
+		if passphrase != confirm {
+			return fmt.Errorf("passphrases do not match")
+		}
+		if passphrase == "" {
+			return usagef("empty passphrase")
+		}
+
+		kind := tokenKindPAT
+		if *apiToken {
+			kind = tokenKindAPIToken
+		}
+		if err := store.Set(config.BaseURL, kind, token, passphrase); err != nil {
+			return err
+		}
+		if err := store.save(); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Token for %s stored in %s\n", tokenStoreKey(config.BaseURL), store.path)
+		fmt.Println("Remove JIRA_PAT and JIRA_API_TOKEN from your .env and environment so the stored token is used.")
+		return nil
+
+	case "rm":
+		key := tokenStoreKey(config.BaseURL)
+		if _, ok := store.Entries[key]; !ok {
+			return fmt.Errorf("no token stored for %s", key)
+		}
+		delete(store.Entries, key)
+		if err := store.save(); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Token for %s removed\n", key)
+		return nil
+
+	default:
+		return usagef("unknown token subcommand %q", args[0])
+	}
+}
+
+func runOAuth1Command(ctx context.Context, client *JiraClient, args []string) error {
+	if len(args) == 0 || args[0] != "login" {
+		return usagef("expected oauth1 login")
+	}
+	fs := newFlagSet("oauth1 login")
+	positional, err := parseArgs(fs, args[1:])
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("oauth1 login takes no arguments")
+	}
+
+	config, _, err := loadSettings(*profileFlag)
+	if err != nil {
+		return err
+	}
+	if config.BaseURL == "" || config.OAuthConsumerKey == "" || config.OAuthPrivateKey == "" {
+		return fmt.Errorf("JIRA_BASE_URL, JIRA_OAUTH_CONSUMER_KEY and JIRA_OAUTH_PRIVATE_KEY must be set")
+	}
+
+	token, err := oauth1Authorize(ctx, config, bufio.NewScanner(os.Stdin))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Access granted. Add this to your profile or environment:\n")
+	fmt.Printf("  JIRA_AUTH=oauth1\n")
+	fmt.Printf("  JIRA_OAUTH_TOKEN=%s\n", token)
+	return nil
+}
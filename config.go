@@ -4,43 +4,136 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 // Config holds the JIRA configuration
 type Config struct {
+	Profile  string // name of the profile in use, empty when none is
 	BaseURL  string
 	Username string
 	APIToken string
-	UsePAT   bool // indicates if we're using Personal Access Token (Bearer auth)
+	AuthMode string // basic, pat, cookie or oauth1 from JIRA_AUTH; empty probes /myself
+
+	TokenSource string // where the token was found, e.g. "JIRA_PAT_COMMAND"; never the token itself
+
+	// OAuth 1.0a application link credentials, used when AuthMode is oauth1
+	OAuthConsumerKey string
+	OAuthPrivateKey  string // path to the PEM encoded RSA private key
+	OAuthToken       string // access token, see `jeera oauth1 login`
+
+	// Display names (or IDs) of the custom fields jeera models directly
+	StoryPointsField        string
+	AcceptanceCriteriaField string
+
+	// Watchers added to every issue created with one of AutoWatchTypes, by user name
+	AutoWatchers   []string
+	AutoWatchTypes []string
+
+	// Network behaviour
+	RequestTimeout time.Duration // timeout of a single HTTP attempt
+	MaxRetries     int           // retries after the first attempt, 0 disables retrying
+	RetryBaseDelay time.Duration // first backoff delay, doubled on every retry
+	RateLimit      float64       // maximum requests per second, 0 disables limiting
+}
+
+// LoadConfig loads configuration from the environment, the profile config file and .env.
+// Settings are resolved in this order: the profile named by the --profile flag,
+// environment variables, the profile named by JEERA_PROFILE or selected with
+// `jeera config use`, and finally the .env file.
+func LoadConfig(profileFlag string) (*Config, error) {
+	config, src, err := loadSettings(profileFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := src.resolveCredentials(config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
 }
 
-// LoadConfig loads configuration from .env file and environment variables
-func LoadConfig() *Config {
+// loadSettings resolves every setting except the token, which may need to run
+// a command or ask for a passphrase, and returns the sources it used
+func loadSettings(profileFlag string) (*Config, configSource, error) {
 	// Try to load .env file (ignore error if file doesn't exist)
-	if err := loadEnvFile(); err != nil {
-		fmt.Printf("Info: No .env file found, using environment variables only\n")
+	envFile, err := loadEnvFile()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Info: No .env file found, using environment variables only\n")
+	}
+
+	profiles, err := LoadProfiles()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	name := profileFlag
+	if name == "" {
+		name = envSetting("JEERA_PROFILE")
+	}
+	if name == "" {
+		name = profiles.Current
+	}
+
+	src := configSource{os.Getenv}
+	if name != "" {
+		profile, ok := profiles.Profiles[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("profile %q not found in %s", name, profiles.path)
+		}
+		if profileFlag != "" {
+			src = configSource{profile.lookup, os.Getenv}
+		} else {
+			src = append(src, profile.lookup)
+		}
 	}
+	src = append(src, func(key string) string { return envFile[key] })
 
 	config := &Config{
-		BaseURL:  getEnvOrDefault("JIRA_BASE_URL", ""),
-		Username: getEnvOrDefault("JIRA_USERNAME", ""),
-		APIToken: getAPIToken(),
+		Profile:  name,
+		BaseURL:  strings.TrimRight(src.getOrDefault("JIRA_BASE_URL", ""), "/"),
+		Username: src.getOrDefault("JIRA_USERNAME", ""),
+
+		StoryPointsField:        src.getOrDefault("JIRA_FIELD_STORY_POINTS", "Story Points"),
+		AcceptanceCriteriaField: src.getOrDefault("JIRA_FIELD_ACCEPTANCE_CRITERIA", "Acceptance Criteria"),
+
+		AutoWatchers:   src.getList("JIRA_AUTO_WATCHERS", ""),
+		AutoWatchTypes: src.getList("JIRA_AUTO_WATCH_TYPES", "Bug"),
+
+		RequestTimeout: src.getDuration("JIRA_TIMEOUT", defaultRequestTimeout),
+		MaxRetries:     src.getInt("JIRA_MAX_RETRIES", defaultMaxRetries),
+		RetryBaseDelay: src.getDuration("JIRA_RETRY_DELAY", defaultRetryBaseDelay),
+		RateLimit:      src.getFloat("JIRA_RATE_LIMIT", defaultRateLimit),
+
+		AuthMode:         strings.ToLower(src.get("JIRA_AUTH")),
+		OAuthConsumerKey: src.get("JIRA_OAUTH_CONSUMER_KEY"),
+		OAuthPrivateKey:  src.get("JIRA_OAUTH_PRIVATE_KEY"),
+		OAuthToken:       src.get("JIRA_OAUTH_TOKEN"),
 	}
 
-	// Determine authentication method based on token format or explicit setting
-	config.UsePAT = detectPATUsage()
+	if !validAuthMode(config.AuthMode) {
+		return nil, nil, fmt.Errorf("invalid JIRA_AUTH=%q, expected basic, pat, cookie or oauth1", config.AuthMode)
+	}
+	// JIRA_USE_PAT predates JIRA_AUTH and still selects Bearer auth
+	if config.AuthMode == "" && src.get("JIRA_USE_PAT") == "true" {
+		config.AuthMode = authPAT
+	}
 
-	return config
+	return config, src, nil
 }
 
-// loadEnvFile attempts to load environment variables from .env file
-func loadEnvFile() error {
+// readEnvFile reads variables from the first .env file found without changing the environment
+func readEnvFile() (map[string]string, error) {
 	// Get current working directory
 	cwd, err := os.Getwd()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Try multiple possible locations for .env file
@@ -53,56 +146,216 @@ func loadEnvFile() error {
 
 	var lastErr error
 	for _, path := range envPaths {
-		if err := godotenv.Load(path); err == nil {
-			fmt.Printf("Loaded configuration from: %s\n", path)
-			return nil
+		if values, err := godotenv.Read(path); err == nil {
+			fmt.Fprintf(os.Stderr, "Loaded configuration from: %s\n", path)
+			return values, nil
 		} else {
 			lastErr = err
 		}
 	}
 
-	return lastErr
+	return nil, lastErr
 }
 
-// getEnvOrDefault returns the environment variable value or a default value
-func getEnvOrDefault(key, defaultValue string) string {
+// envFileCache holds the .env file, which is read once per run
+var envFileCache struct {
+	once   sync.Once
+	values map[string]string
+	err    error
+}
+
+// loadEnvFile returns the variables of the .env file, reading it on first use
+func loadEnvFile() (map[string]string, error) {
+	envFileCache.once.Do(func() {
+		envFileCache.values, envFileCache.err = readEnvFile()
+	})
+	return envFileCache.values, envFileCache.err
+}
+
+// envSetting returns a setting read before the profiles, such as JEERA_CONFIG,
+// from the environment or else the .env file, like loadSettings does
+func envSetting(key string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
+	values, _ := loadEnvFile()
+	return values[key]
+}
+
+// configSource looks settings up by environment variable name in a list of
+// sources, the first non-empty value winning
+type configSource []func(key string) string
+
+// get returns the first non-empty value of key
+func (src configSource) get(key string) string {
+	for _, lookup := range src {
+		if value := lookup(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// getOrDefault returns the value of key or a default value
+func (src configSource) getOrDefault(key, defaultValue string) string {
+	if value := src.get(key); value != "" {
+		return value
+	}
 	return defaultValue
 }
 
-// getAPIToken gets the API token from either JIRA_API_TOKEN or JIRA_PAT
-func getAPIToken() string {
-	// Try JIRA_PAT first (Personal Access Token), then JIRA_API_TOKEN for backward compatibility
-	if token := os.Getenv("JIRA_PAT"); token != "" {
-		return token
+// getList returns the value of key, or a default value, split at commas
+func (src configSource) getList(key, defaultValue string) []string {
+	var list []string
+	for _, item := range strings.Split(src.getOrDefault(key, defaultValue), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getInt returns the value of key as a non-negative integer or a default value
+func (src configSource) getInt(key string, defaultValue int) int {
+	value := src.get(key)
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		fmt.Fprintf(os.Stderr, "Warning: invalid %s=%q, using %d\n", key, value, defaultValue)
+		return defaultValue
+	}
+	return n
+}
+
+// getFloat returns the value of key as a non-negative number or a default value
+func (src configSource) getFloat(key string, defaultValue float64) float64 {
+	value := src.get(key)
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 {
+		fmt.Fprintf(os.Stderr, "Warning: invalid %s=%q, using %g\n", key, value, defaultValue)
+		return defaultValue
+	}
+	return n
+}
+
+// getDuration returns the value of key as a duration ("30s", "500ms") or a default value
+func (src configSource) getDuration(key string, defaultValue time.Duration) time.Duration {
+	value := src.get(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		fmt.Fprintf(os.Stderr, "Warning: invalid %s=%q, using %v\n", key, value, defaultValue)
+		return defaultValue
 	}
-	return os.Getenv("JIRA_API_TOKEN")
+	return d
 }
 
-// detectPATUsage determines if we should use PAT (Bearer) authentication
-func detectPATUsage() bool {
-	// If JIRA_PAT is explicitly set, use PAT authentication
-	if os.Getenv("JIRA_PAT") != "" {
-		return true
+// resolveCredentials sets the token. Every source is asked in turn for JIRA_PAT,
+// JIRA_API_TOKEN and JIRA_PAT_COMMAND; without any of them the token is looked up
+// in ~/.netrc and then in the encrypted token store. OAuth needs no token.
+func (src configSource) resolveCredentials(config *Config) error {
+	return src.findCredentials(config, true)
+}
+
+// describeCredentials sets only where the token would come from, for display.
+// Unlike resolveCredentials it never runs JIRA_PAT_COMMAND or asks for the
+// token store passphrase.
+func (src configSource) describeCredentials(config *Config) error {
+	return src.findCredentials(config, false)
+}
+
+// findCredentials looks the token up as described at resolveCredentials. Without
+// resolve, a command or the token store is only recorded as the source.
+func (src configSource) findCredentials(config *Config, resolve bool) error {
+	if config.AuthMode == authOAuth1 {
+		return nil
+	}
+
+	for _, lookup := range src {
+		// JIRA_PAT (Personal Access Token) first, then JIRA_API_TOKEN for backward compatibility
+		if token := lookup("JIRA_PAT"); token != "" {
+			config.APIToken, config.TokenSource = token, "JIRA_PAT"
+			return nil
+		}
+		if token := lookup("JIRA_API_TOKEN"); token != "" {
+			config.APIToken, config.TokenSource = token, "JIRA_API_TOKEN"
+			return nil
+		}
+		if command := lookup("JIRA_PAT_COMMAND"); command != "" {
+			config.TokenSource = "JIRA_PAT_COMMAND"
+			if !resolve {
+				return nil
+			}
+			token, err := tokenFromCommand(command)
+			if err != nil {
+				return err
+			}
+			config.APIToken = token
+			return nil
+		}
+	}
+
+	login, password, err := netrcCredentials(config.BaseURL)
+	if err != nil {
+		return err
+	}
+	if password != "" && (login == "" || config.Username == "" || strings.EqualFold(login, config.Username)) {
+		if config.Username == "" {
+			config.Username = login
+		}
+		config.APIToken, config.TokenSource = password, netrcPath()
+		return nil
+	}
+
+	store, err := loadTokenStore()
+	if err != nil {
+		return err
+	}
+	entry, ok := store.Entries[tokenStoreKey(config.BaseURL)]
+	if !ok {
+		return nil
+	}
+	config.TokenSource = "token store"
+	// the kind was chosen explicitly with `jeera token set`
+	if config.AuthMode == "" && entry.Kind == tokenKindPAT {
+		config.AuthMode = authPAT
+	} else if config.AuthMode == "" {
+		config.AuthMode = authBasic
 	}
-	
-	// If JIRA_USE_PAT is explicitly set to true, use PAT authentication
-	if getEnvOrDefault("JIRA_USE_PAT", "false") == "true" {
-		return true
+	if !resolve {
+		return nil
 	}
-	
-	// Check if token looks like a PAT (typically longer and different format)
-	token := getAPIToken()
-	if len(token) > 50 { // PATs are typically much longer than API tokens
-		return true
+
+	token, _, err := storedToken(config.BaseURL)
+	if err != nil {
+		return err
+	}
+	config.APIToken = token
+	return nil
+}
+
+// getEnvOrDefault returns the environment variable value or a default value
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
 	}
-	
-	return false
+	return defaultValue
 }
 
 // Validate checks if all required configuration values are present
 func (c *Config) Validate() bool {
+	switch c.AuthMode {
+	case authOAuth1:
+		return c.BaseURL != "" && c.OAuthConsumerKey != "" && c.OAuthPrivateKey != "" && c.OAuthToken != ""
+	case authPAT:
+		return c.BaseURL != "" && c.APIToken != ""
+	}
 	return c.BaseURL != "" && c.Username != "" && c.APIToken != ""
 }
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// editText opens $VISUAL or $EDITOR (falling back to vi) on a temporary file
+// pre-filled with initial, and returns the text once the editor exits
+func editText(initial string) (string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = getEnvOrDefault("EDITOR", "vi")
+	}
+
+	file, err := os.CreateTemp("", "jeera-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %v", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(initial); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to write temporary file: %v", err)
+	}
+	file.Close()
+
+	// the editor setting may carry arguments, e.g. "code --wait"
+	args := append(strings.Fields(editor), file.Name())
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor %q failed: %v", editor, err)
+	}
+
+	text, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read temporary file: %v", err)
+	}
+
+	return strings.TrimSpace(string(text)), nil
+}
+
+// stdinIsTerminal reports whether stdin is attached to an interactive terminal
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// readBody resolves a multi-line text argument for the non-interactive commands:
+// the flag value when given, stdin when the value is "-" or stdin is piped,
+// and the editor otherwise
+func readBody(value, initial string) (string, error) {
+	if value != "" && value != "-" {
+		return value, nil
+	}
+
+	if value == "-" || !stdinIsTerminal() {
+		text, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read stdin: %v", err)
+		}
+		return strings.TrimSpace(string(text)), nil
+	}
+
+	return editText(initial)
+}
+
+// readMultiline reads lines from the menu scanner until a line containing only "."
+// or end of input. An empty first line opens the editor instead.
+func readMultiline(scanner *bufio.Scanner, initial string) (string, error) {
+	fmt.Println("(end with a line containing only '.', or press Enter on the first line to open $EDITOR)")
+
+	var lines []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(lines) == 0 && strings.TrimSpace(line) == "" {
+			return editText(initial)
+		}
+		if strings.TrimSpace(line) == "." {
+			break
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n")), nil
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxErrorBodyLength caps how much of a non-JSON error body ends up in an error message
+const maxErrorBodyLength = 300
+
+// APIError is returned when JIRA answers a request with an unexpected status
+type APIError struct {
+	Op         string // what jeera was trying to do, e.g. "create issue"
+	Method     string
+	Endpoint   string
+	StatusCode int
+
+	// ErrorMessages and Errors are decoded from JIRA's standard error body;
+	// Errors maps field IDs to the message for that field
+	ErrorMessages []string
+	Errors        map[string]string
+
+	// Body is the raw response body, kept for bodies that are not JIRA error JSON
+	Body string
+
+	// fieldNames maps field IDs to display names for friendlier messages
+	fieldNames map[string]string
+}
+
+func (e *APIError) Error() string {
+	details := e.Messages()
+	if len(details) == 0 {
+		body := strings.TrimSpace(e.Body)
+		if len(body) > maxErrorBodyLength {
+			body = body[:maxErrorBodyLength] + "..."
+		}
+		if body == "" {
+			body = http.StatusText(e.StatusCode)
+		}
+		details = []string{body}
+	}
+
+	return fmt.Sprintf("failed to %s: %s (status %d, %s %s)", e.Op, strings.Join(details, "; "), e.StatusCode, e.Method, e.Endpoint)
+}
+
+// Messages returns the general messages followed by "Field: message" for every field error
+func (e *APIError) Messages() []string {
+	messages := append([]string{}, e.ErrorMessages...)
+
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		messages = append(messages, fmt.Sprintf("%s: %s", e.FieldName(field), e.Errors[field]))
+	}
+
+	return messages
+}
+
+// FieldName returns the display name of a field ID, or the ID when it is unknown
+func (e *APIError) FieldName(fieldID string) string {
+	if name, ok := e.fieldNames[fieldID]; ok {
+		return name
+	}
+	return fieldID
+}
+
+// FieldError returns the message JIRA reported for a field, by ID or display name
+func (e *APIError) FieldError(field string) (string, bool) {
+	for id, message := range e.Errors {
+		if id == field || strings.EqualFold(e.FieldName(id), field) {
+			return message, true
+		}
+	}
+	return "", false
+}
+
+// IsNotFound reports whether err is a JIRA 404 response
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err is a JIRA 401 response, i.e. invalid or expired credentials
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is a JIRA 403 response
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsFieldError reports whether err carries an error for the given field ID or display name
+func IsFieldError(err error, field string) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	_, ok := apiErr.FieldError(field)
+	return ok
+}
+
+// hasStatus reports whether err is an APIError with the given status code
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// apiError consumes the body of an unexpected response and turns it into an *APIError
+func (client *JiraClient) apiError(resp *http.Response, op string) error {
+	bodyBytes, _ := io.ReadAll(resp.Body)
+
+	apiErr := &APIError{
+		Op:         op,
+		StatusCode: resp.StatusCode,
+		Body:       string(bodyBytes),
+		fieldNames: client.knownFieldNames(),
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Endpoint = resp.Request.URL.Path
+	}
+
+	var body struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if err := json.Unmarshal(bodyBytes, &body); err == nil {
+		apiErr.ErrorMessages = body.ErrorMessages
+		apiErr.Errors = body.Errors
+	}
+
+	return apiErr
+}
+
+// knownFieldNames maps field IDs to display names using only the field lists
+// already in memory or in the disk cache, so building an error never makes a request
+func (client *JiraClient) knownFieldNames() map[string]string {
+	fields := client.fieldList
+	if fields == nil {
+		if path := fieldCachePath(client.config.BaseURL); path != "" {
+			if cache, err := readFieldCache(path); err == nil && cache.BaseURL == client.config.BaseURL && time.Since(cache.Fetched) < fieldCacheTTL {
+				fields = cache.Fields
+			}
+		}
+	}
+
+	names := make(map[string]string, len(fields))
+	for _, f := range fields {
+		names[f.ID] = f.Name
+	}
+	return names
+}
@@ -0,0 +1,503 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// fieldCacheTTL is how long the discovered field list is reused before it is fetched again
+const fieldCacheTTL = 24 * time.Hour
+
+// FieldSchema describes the type of a JIRA field
+type FieldSchema struct {
+	Type     string `json:"type"`
+	Items    string `json:"items,omitempty"`
+	System   string `json:"system,omitempty"`
+	Custom   string `json:"custom,omitempty"`
+	CustomID int    `json:"customId,omitempty"`
+}
+
+// Field represents a field definition as returned by /rest/api/2/field
+type Field struct {
+	ID     string      `json:"id"`
+	Name   string      `json:"name"`
+	Custom bool        `json:"custom"`
+	Schema FieldSchema `json:"schema"`
+}
+
+// FieldMeta represents a field of an issue's edit screen as returned by editmeta
+type FieldMeta struct {
+	FieldID       string            `json:"fieldId"`
+	Name          string            `json:"name"`
+	Required      bool              `json:"required"`
+	Schema        FieldSchema       `json:"schema"`
+	Operations    []string          `json:"operations"`
+	AllowedValues []json.RawMessage `json:"allowedValues,omitempty"`
+}
+
+// fieldCache is the on-disk format of the discovered field list
+type fieldCache struct {
+	BaseURL string    `json:"baseUrl"`
+	Fetched time.Time `json:"fetched"`
+	Fields  []Field   `json:"fields"`
+}
+
+// GetFields retrieves every system and custom field defined on the instance
+func (client *JiraClient) GetFields(ctx context.Context) ([]Field, error) {
+	resp, err := client.makeRequest(ctx, "GET", "/rest/api/2/field", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.apiError(resp, "get fields")
+	}
+
+	var fields []Field
+	if err := json.NewDecoder(resp.Body).Decode(&fields); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	return fields, nil
+}
+
+// GetEditMeta retrieves the fields that can be edited on an issue, keyed by field ID
+func (client *JiraClient) GetEditMeta(ctx context.Context, issueIDOrKey string) (map[string]FieldMeta, error) {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/editmeta", issueIDOrKey)
+
+	resp, err := client.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.apiError(resp, "get edit metadata")
+	}
+
+	var result struct {
+		Fields map[string]FieldMeta `json:"fields"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	fillFieldIDs(result.Fields)
+	return result.Fields, nil
+}
+
+// fillFieldIDs sets FieldID from the map key where JIRA left it out, as older instances do
+func fillFieldIDs(fields map[string]FieldMeta) {
+	for id, meta := range fields {
+		if meta.FieldID == "" {
+			meta.FieldID = id
+			fields[id] = meta
+		}
+	}
+}
+
+// UpdateFields sets issue fields keyed by field ID, leaving every other field untouched
+func (client *JiraClient) UpdateFields(ctx context.Context, issueIDOrKey string, fields map[string]interface{}) error {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s", issueIDOrKey)
+
+	resp, err := client.makeRequest(ctx, "PUT", endpoint, map[string]interface{}{"fields": fields})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return client.apiError(resp, "update fields")
+	}
+
+	return nil
+}
+
+// fields returns the field list, using the in-memory copy, then the disk cache,
+// then /rest/api/2/field. refresh skips both caches.
+func (client *JiraClient) fields(ctx context.Context, refresh bool) ([]Field, error) {
+	if client.fieldList != nil && !refresh {
+		return client.fieldList, nil
+	}
+
+	path := fieldCachePath(client.config.BaseURL)
+	if !refresh && path != "" {
+		if cache, err := readFieldCache(path); err == nil && cache.BaseURL == client.config.BaseURL && time.Since(cache.Fetched) < fieldCacheTTL {
+			client.fieldList = cache.Fields
+			return client.fieldList, nil
+		}
+	}
+
+	fields, err := client.GetFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client.fieldList = fields
+
+	if path != "" {
+		cache := fieldCache{BaseURL: client.config.BaseURL, Fetched: time.Now(), Fields: fields}
+		if err := writeFieldCache(path, cache); err != nil && *DEBUGflag {
+			fmt.Printf("Failed to write field cache: %v\n", err)
+		}
+	}
+
+	return fields, nil
+}
+
+// LookupField finds a field by ID or by case-insensitive display name
+func (client *JiraClient) LookupField(ctx context.Context, nameOrID string) (*Field, error) {
+	fields, err := client.fields(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return matchField(fields, nameOrID)
+}
+
+// FieldID resolves a display name such as "Story Points" to its field ID.
+// IDs are returned unchanged; custom field IDs are trusted even when discovery fails.
+func (client *JiraClient) FieldID(ctx context.Context, nameOrID string) (string, error) {
+	field, err := client.LookupField(ctx, nameOrID)
+	if err != nil {
+		if strings.HasPrefix(nameOrID, "customfield_") {
+			return nameOrID, nil
+		}
+		return "", err
+	}
+	return field.ID, nil
+}
+
+// LookupFieldForIssue resolves a field by ID or display name among the fields
+// editable on a given issue, which disambiguates fields sharing a name
+func (client *JiraClient) LookupFieldForIssue(ctx context.Context, issueIDOrKey, nameOrID string) (*FieldMeta, error) {
+	meta, err := client.GetEditMeta(ctx, issueIDOrKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if m, ok := meta[nameOrID]; ok {
+		return &m, nil
+	}
+
+	var matches []FieldMeta
+	for _, m := range meta {
+		if strings.EqualFold(m.Name, nameOrID) {
+			matches = append(matches, m)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("field %q cannot be edited on %s", nameOrID, issueIDOrKey)
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, m := range matches {
+			ids = append(ids, m.FieldID)
+		}
+		return nil, fmt.Errorf("ambiguous field name %q on %s (matches %s), use the field ID", nameOrID, issueIDOrKey, strings.Join(ids, ", "))
+	}
+}
+
+// matchField finds a field by exact ID or by unique case-insensitive name
+func matchField(fields []Field, nameOrID string) (*Field, error) {
+	for i := range fields {
+		if fields[i].ID == nameOrID {
+			return &fields[i], nil
+		}
+	}
+
+	var matches []*Field
+	for i := range fields {
+		if strings.EqualFold(fields[i].Name, nameOrID) {
+			matches = append(matches, &fields[i])
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("unknown field %q", nameOrID)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, f := range matches {
+			ids = append(ids, f.ID)
+		}
+		return nil, fmt.Errorf("ambiguous field name %q (matches %s), use the field ID", nameOrID, strings.Join(ids, ", "))
+	}
+}
+
+// fieldCachePath returns the cache file for an instance, or "" when there is no cache directory
+func fieldCachePath(baseURL string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	host := baseURL
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	host = strings.NewReplacer(":", "_", "/", "_").Replace(host)
+
+	return filepath.Join(dir, "jeera", "fields-"+host+".json")
+}
+
+// readFieldCache loads a cached field list from disk
+func readFieldCache(path string) (*fieldCache, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cache fieldCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, err
+	}
+	return &cache, nil
+}
+
+// writeFieldCache stores the field list on disk
+func writeFieldCache(path string, cache fieldCache) error {
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// fieldValue converts a value typed by the user into the JSON shape the field schema expects
+func fieldValue(schema FieldSchema, value string) (interface{}, error) {
+	switch schema.Type {
+	case "number":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", value)
+		}
+		return n, nil
+	case "string", "date", "datetime":
+		return value, nil
+	case "option":
+		return map[string]string{"value": value}, nil
+	case "user":
+		return map[string]string{"name": value}, nil
+	case "priority", "issuetype", "version", "component", "resolution", "securitylevel":
+		return map[string]string{"name": value}, nil
+	case "array":
+		var items []interface{}
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			v, err := fieldValue(FieldSchema{Type: schema.Items}, item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		}
+		return items, nil
+	}
+
+	// unknown types accept raw JSON, falling back to a plain string
+	var raw interface{}
+	if err := json.Unmarshal([]byte(value), &raw); err == nil {
+		return raw, nil
+	}
+	return value, nil
+}
+
+// metaFieldValue converts user input for a field described by editmeta or a transition
+// screen. When JIRA lists allowed values the input must match one of them by ID, name
+// or value; otherwise it is converted by schema like fieldValue.
+func metaFieldValue(meta FieldMeta, value string) (interface{}, error) {
+	if len(meta.AllowedValues) == 0 {
+		return fieldValue(meta.Schema, value)
+	}
+
+	if meta.Schema.Type != "array" {
+		return matchAllowedValue(meta, value)
+	}
+
+	var items []interface{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		v, err := matchAllowedValue(meta, item)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+	}
+	return items, nil
+}
+
+// matchAllowedValue finds value among the allowed values of a field and returns a reference to it by ID
+func matchAllowedValue(meta FieldMeta, value string) (interface{}, error) {
+	names := make([]string, 0, len(meta.AllowedValues))
+	for _, raw := range meta.AllowedValues {
+		var allowed struct {
+			ID    string `json:"id"`
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		}
+		if err := json.Unmarshal(raw, &allowed); err != nil {
+			continue
+		}
+		if allowed.ID != "" && (allowed.ID == value || strings.EqualFold(allowed.Name, value) || strings.EqualFold(allowed.Value, value)) {
+			return map[string]string{"id": allowed.ID}, nil
+		}
+		names = append(names, formatFieldValue(raw))
+	}
+	return nil, fmt.Errorf("%q is not an allowed value for %s (allowed: %s)", value, meta.Name, strings.Join(names, ", "))
+}
+
+// formatFieldValue renders a raw field value as a short human-readable string
+func formatFieldValue(raw json.RawMessage) string {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return string(raw)
+	}
+	return formatValue(value)
+}
+
+// formatValue renders a decoded JSON value, preferring the names JIRA objects carry
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, formatValue(item))
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		for _, key := range []string{"displayName", "name", "value", "key"} {
+			if s, ok := v[key].(string); ok {
+				return s
+			}
+		}
+	}
+
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// parseFieldAssignments parses "Name=value" pairs given on the command line or in the menu
+func parseFieldAssignments(assignments []string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, assignment := range assignments {
+		name, value, ok := strings.Cut(assignment, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid field assignment %q, expected Name=value", assignment)
+		}
+		result[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return result, nil
+}
+
+// resolveCustomFields converts "Name=value" assignments into field ID keyed values
+// using the instance-wide field list
+func (client *JiraClient) resolveCustomFields(ctx context.Context, assignments map[string]string) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	for name, value := range assignments {
+		field, err := client.LookupField(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		v, err := fieldValue(field.Schema, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", field.Name, err)
+		}
+		result[field.ID] = v
+	}
+	return result, nil
+}
+
+// printFields prints the fields whose name or ID contains filter
+func printFields(fields []Field, filter string) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tID\tTYPE")
+	for _, f := range filterFields(fields, filter) {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", f.Name, f.ID, schemaString(f.Schema))
+	}
+	w.Flush()
+}
+
+// filterFields returns the fields whose name or ID contains filter, sorted by name
+func filterFields(fields []Field, filter string) []Field {
+	var matching []Field
+	for _, f := range fields {
+		if fieldMatchesFilter(f.Name, f.ID, filter) {
+			matching = append(matching, f)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool { return strings.ToLower(matching[i].Name) < strings.ToLower(matching[j].Name) })
+	return matching
+}
+
+// printEditMeta prints the editable fields of an issue whose name or ID contains filter
+func printEditMeta(meta map[string]FieldMeta, filter string) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tID\tTYPE\tREQUIRED\tOPERATIONS\tALLOWED VALUES")
+	for _, m := range filterEditMeta(meta, filter) {
+		allowed := make([]string, 0, len(m.AllowedValues))
+		for _, v := range m.AllowedValues {
+			allowed = append(allowed, formatFieldValue(v))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%s\n", m.Name, m.FieldID, schemaString(m.Schema), m.Required,
+			strings.Join(m.Operations, ","), strings.Join(allowed, ", "))
+	}
+	w.Flush()
+}
+
+// filterEditMeta returns the editable fields whose name or ID contains filter, sorted by name
+func filterEditMeta(meta map[string]FieldMeta, filter string) []FieldMeta {
+	var matching []FieldMeta
+	for _, m := range meta {
+		if fieldMatchesFilter(m.Name, m.FieldID, filter) {
+			matching = append(matching, m)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool { return strings.ToLower(matching[i].Name) < strings.ToLower(matching[j].Name) })
+	return matching
+}
+
+// fieldMatchesFilter reports whether a field name or ID contains filter, ignoring case
+func fieldMatchesFilter(name, id, filter string) bool {
+	filter = strings.ToLower(filter)
+	return strings.Contains(strings.ToLower(name), filter) || strings.Contains(strings.ToLower(id), filter)
+}
+
+// schemaString renders a schema as "type" or "array<items>"
+func schemaString(schema FieldSchema) string {
+	if schema.Type == "array" && schema.Items != "" {
+		return "array<" + schema.Items + ">"
+	}
+	return schema.Type
+}
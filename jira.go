@@ -2,18 +2,26 @@ package main
 
 import (
 	"bytes"
-	"encoding/base64"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 )
 
 // JiraClient represents a JIRA API client
 type JiraClient struct {
-	config     *Config
-	httpClient *http.Client
+	config      *Config
+	httpClient  *http.Client
+	transfers   *http.Client // attachment uploads and downloads, see newTransferClient
+	limiter     *rateLimiter
+	fieldList   []Field // discovered field definitions, loaded on first use
+	currentUser *User   // owner of the credentials, loaded on first use
+
+	authMu sync.Mutex
+	auth   Authenticator // chosen from JIRA_AUTH or probed on first use
 }
 
 // NewJiraClient creates a new JIRA client
@@ -21,8 +29,10 @@ func NewJiraClient(config *Config) *JiraClient {
 	return &JiraClient{
 		config: config,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: config.RequestTimeout,
 		},
+		transfers: newTransferClient(config.RequestTimeout),
+		limiter:   newRateLimiter(config.RateLimit),
 	}
 }
 
@@ -41,9 +51,78 @@ type IssueFields struct {
 	Project              *Project    `json:"project,omitempty"`
 	Priority             *Priority   `json:"priority,omitempty"`
 	Status               *Status     `json:"status,omitempty"`
-	AcceptanceCriteria   string      `json:"customfield_11028"`  // Replace with your actual custom field ID
-	StoryPoints          float32     `json:"customfield_10002"`  // Replace with your actual custom field ID
+	AcceptanceCriteria   string      `json:"-"` // custom field discovered by name, see Config.AcceptanceCriteriaField
+	StoryPoints          *float32    `json:"-"` // custom field discovered by name, see Config.StoryPointsField; nil when unset
 	Assignee             *Assignee   `json:"assignee,omitempty"`
+	Labels               []string    `json:"labels,omitempty"`
+	Components           []Component `json:"components,omitempty"`
+	FixVersions          []Version   `json:"fixVersions,omitempty"`
+	Versions             []Version   `json:"versions,omitempty"` // affects versions
+	IssueLinks           []IssueLink `json:"issuelinks,omitempty"`
+
+	// Custom holds additional values to send, keyed by field ID
+	Custom map[string]interface{} `json:"-"`
+	// Raw holds every field as returned by JIRA, keyed by field ID
+	Raw map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and keeps every raw field for name-based lookups
+func (f *IssueFields) UnmarshalJSON(data []byte) error {
+	type plain IssueFields
+	if err := json.Unmarshal(data, (*plain)(f)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &f.Raw)
+}
+
+// MarshalJSON writes the fields as JIRA returned them when they were decoded from
+// a response, so custom fields survive in the JSON and YAML output
+func (f IssueFields) MarshalJSON() ([]byte, error) {
+	if f.Raw != nil {
+		return json.Marshal(f.Raw)
+	}
+	type plain IssueFields
+	return json.Marshal(plain(f))
+}
+
+// StatusName returns the status name, or "" when the status was not loaded
+func (f *IssueFields) StatusName() string {
+	if f.Status == nil {
+		return ""
+	}
+	return f.Status.Name
+}
+
+// IssueTypeName returns the issue type name, or "" when it was not loaded
+func (f *IssueFields) IssueTypeName() string {
+	if f.IssueType == nil {
+		return ""
+	}
+	return f.IssueType.Name
+}
+
+// PriorityName returns the priority name, or "" when it was not loaded
+func (f *IssueFields) PriorityName() string {
+	if f.Priority == nil {
+		return ""
+	}
+	return f.Priority.Name
+}
+
+// Points returns the story points, or 0 when they are not set
+func (f *IssueFields) Points() float32 {
+	if f.StoryPoints == nil {
+		return 0
+	}
+	return *f.StoryPoints
+}
+
+// AssigneeName returns the display name of the assignee, or "" when unassigned
+func (f *IssueFields) AssigneeName() string {
+	if f.Assignee == nil {
+		return ""
+	}
+	return f.Assignee.DisplayName
 }
 
 // IssueType represents a JIRA issue type
@@ -66,13 +145,35 @@ type Priority struct {
 
 // Status represents a JIRA status
 type Status struct {
+	ID       string          `json:"id,omitempty"`
+	Name     string          `json:"name"`
+	Category *StatusCategory `json:"statusCategory,omitempty"`
+}
+
+// StatusCategory groups statuses into to do, in progress and done
+type StatusCategory struct {
+	ID   int    `json:"id,omitempty"`
+	Key  string `json:"key"` // "new", "indeterminate" or "done"
+	Name string `json:"name"`
+}
+
+// Component represents a project component
+type Component struct {
 	ID   string `json:"id,omitempty"`
 	Name string `json:"name"`
 }
 
+// Version represents a project version, used for both fix and affects versions
+type Version struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Released bool   `json:"released,omitempty"`
+	Archived bool   `json:"archived,omitempty"`
+}
+
 // CreateIssueRequest represents the request structure for creating an issue
 type CreateIssueRequest struct {
-	Fields IssueFields `json:"fields"`
+	Fields map[string]interface{} `json:"fields"`
 }
 
 // CreateIssueResponse represents the response from creating an issue
@@ -86,6 +187,15 @@ type CreateIssueResponse struct {
 type Transition struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
+	// To is the status the issue ends up in
+	To *Status `json:"to,omitempty"`
+	// HasScreen is set when JIRA shows a screen for the transition
+	HasScreen bool `json:"hasScreen,omitempty"`
+	// IsGlobal is set for transitions available from every status of the workflow
+	IsGlobal bool `json:"isGlobal,omitempty"`
+
+	// Fields are the fields on the transition screen, keyed by field ID
+	Fields map[string]FieldMeta `json:"fields,omitempty"`
 }
 
 type Assignee struct {
@@ -93,66 +203,285 @@ type Assignee struct {
 	DisplayName  string  `json:"displayName,omitempty"`
 }
 
+// Comment represents a comment on a JIRA issue
 type Comment struct {
-	ID      	 string  `json:"id,omitempty"`
-	Body         string  `json:"body"`
-	Author       string  `json:"author,omitempty"`
-	Created      string  `json:"created,omitempty"`
-	LastUpdated  string  `json:"updated,omitempty"`
-	TimeZone     string  `json:"timeZone,omitempty"`
+	ID           string      `json:"id,omitempty"`
+	Body         string      `json:"body"`
+	Author       *User       `json:"author,omitempty"`
+	UpdateAuthor *User       `json:"updateAuthor,omitempty"`
+	Created      time.Time   `json:"created"`
+	Updated      time.Time   `json:"updated"`
+	Visibility   *Visibility `json:"visibility,omitempty"`
 }
 
-// makeRequest performs an HTTP request with authentication
-func (client *JiraClient) makeRequest(method, endpoint string, body interface{}) (*http.Response, error) {
-	var reqBody io.Reader
-	
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %v", err)
+// Visibility restricts a comment to a group or a project role
+type Visibility struct {
+	Type  string `json:"type"` // "group" or "role"
+	Value string `json:"value"`
+}
+
+// jiraTimeLayout is the timestamp format of the JIRA REST API, e.g. 2025-09-08T07:49:29.479+0200
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// parseJiraTime parses a JIRA timestamp; an empty value is the zero time
+func parseJiraTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(jiraTimeLayout, value)
+	if err != nil {
+		// some instances and proxies return RFC 3339 instead
+		if t, rfcErr := time.Parse(time.RFC3339Nano, value); rfcErr == nil {
+			return t, nil
+		}
+		return time.Time{}, fmt.Errorf("invalid timestamp %q", value)
+	}
+	return t, nil
+}
+
+// UnmarshalJSON decodes a comment, parsing the JIRA timestamps
+func (c *Comment) UnmarshalJSON(data []byte) error {
+	type plain Comment
+	var raw struct {
+		*plain
+		Created string `json:"created"`
+		Updated string `json:"updated"`
+	}
+	raw.plain = (*plain)(c)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	if c.Created, err = parseJiraTime(raw.Created); err != nil {
+		return fmt.Errorf("comment %s: %v", c.ID, err)
+	}
+	if c.Updated, err = parseJiraTime(raw.Updated); err != nil {
+		return fmt.Errorf("comment %s: %v", c.ID, err)
+	}
+	return nil
+}
+
+// AuthorName returns the display name of the comment author. Comments of deleted users
+// carry only a user name or no author at all.
+func (c *Comment) AuthorName() string {
+	return userName(c.Author)
+}
+
+// userName returns the best available name of a user embedded in a response
+func userName(u *User) string {
+	switch {
+	case u == nil:
+		return "Anonymous"
+	case u.DisplayName != "":
+		return u.DisplayName
+	case u.Name != "":
+		return u.Name
+	}
+	return u.AccountID
+}
+
+// requestBody is the payload of a request that is not JSON, such as an upload.
+// open is called for every attempt, so a retried request sends the whole body again.
+type requestBody struct {
+	contentType string
+	header      http.Header // extra headers, set after the defaults
+	length      int64       // content length when open returns a plain stream
+	open        func() (io.Reader, error)
+	transfer    bool // a large upload or download, sent without an overall timeout
+}
+
+// makeRequest performs an HTTP request with authentication.
+// Requests are paced by the client's rate limiter and retried with backoff as decided by retryDelay.
+// body is encoded as JSON unless it is a *requestBody.
+func (client *JiraClient) makeRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
+	raw, ok := body.(*requestBody)
+	if !ok {
+		var jsonBody []byte
+		if body != nil {
+			var err error
+			jsonBody, err = json.Marshal(body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal request body: %v", err)
+			}
+		}
+		raw = &requestBody{
+			contentType: "application/json",
+			open: func() (io.Reader, error) {
+				if jsonBody == nil {
+					return nil, nil
+				}
+				return bytes.NewReader(jsonBody), nil
+			},
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
 	}
 
 	url := fmt.Sprintf("%s%s", client.config.BaseURL, endpoint)
-	req, err := http.NewRequest(method, url, reqBody)
+
+	renewed := false
+	maybeApplied := false // an earlier attempt may have been processed by JIRA
+	for attempt := 0; ; attempt++ {
+		// every attempt needs a fresh reader over the same body
+		reqBody, err := raw.open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %v", err)
+		}
+		if raw.length > 0 {
+			req.ContentLength = raw.length
+		}
+
+		// Add authentication
+		auth, err := client.authenticator(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := auth.Authenticate(ctx, req); err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", raw.contentType)
+		req.Header.Set("Accept", "application/json")
+		for name, values := range raw.header {
+			req.Header[name] = values
+		}
+
+		if err := client.limiter.wait(ctx); err != nil {
+			return nil, fmt.Errorf("failed to make request: %w", err)
+		}
+		httpClient := client.httpClient
+		if raw.transfer {
+			httpClient = client.transfers
+		}
+		resp, err := httpClient.Do(req)
+		if resp != nil {
+			client.limiter.observe(resp.Header)
+		}
+
+		// a cancelled request is never retried
+		if ctx.Err() != nil && err != nil {
+			return nil, fmt.Errorf("failed to make request: %w", ctx.Err())
+		}
+
+		// an expired session is renewed once, without counting as a retry
+		if session, ok := auth.(sessionAuthenticator); ok && err == nil && resp.StatusCode == http.StatusUnauthorized && !renewed {
+			renewed = true
+			session.Invalidate()
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			attempt--
+			continue
+		}
+
+		delay, retry := client.retryDelay(method, resp, err, attempt)
+		if !retry {
+			if err != nil {
+				return nil, fmt.Errorf("failed to make request: %w", err)
+			}
+			if maybeApplied && deletedBefore(method, resp) {
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+				return noContent(resp.Request), nil
+			}
+			return resp, nil
+		}
+		if err != nil || resp.StatusCode >= 500 {
+			maybeApplied = true
+		}
+
+		if *DEBUGflag {
+			if err != nil {
+				fmt.Printf("%s %s failed (%v), retrying in %v\n", method, endpoint, err, delay)
+			} else {
+				fmt.Printf("%s %s returned %d, retrying in %v\n", method, endpoint, resp.StatusCode, delay)
+			}
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, fmt.Errorf("failed to make request: %w", err)
+		}
+	}
+}
+
+// fieldsPayload builds the "fields" object for a request, placing the custom
+// fields under the IDs discovered for this instance
+func (client *JiraClient) fieldsPayload(ctx context.Context, fields IssueFields) (map[string]interface{}, error) {
+	data, err := json.Marshal(fields)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
+		return nil, fmt.Errorf("failed to marshal fields: %v", err)
+	}
+	payload := make(map[string]interface{})
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to marshal fields: %v", err)
+	}
+
+	if fields.AcceptanceCriteria != "" {
+		id, err := client.FieldID(ctx, client.config.AcceptanceCriteriaField)
+		if err != nil {
+			return nil, err
+		}
+		payload[id] = fields.AcceptanceCriteria
 	}
+	if fields.StoryPoints != nil {
+		id, err := client.FieldID(ctx, client.config.StoryPointsField)
+		if err != nil {
+			return nil, err
+		}
+		payload[id] = *fields.StoryPoints
+	}
+	for id, value := range fields.Custom {
+		payload[id] = value
+	}
+
+	return payload, nil
+}
 
-	// Add authentication header
-	if client.config.UsePAT {
-		// Use Bearer authentication for Personal Access Tokens
-		req.Header.Set("Authorization", "Bearer "+client.config.APIToken)
-	} else {
-		// Use Basic authentication for API tokens
-		auth := base64.StdEncoding.EncodeToString([]byte(client.config.Username + ":" + client.config.APIToken))
-		req.Header.Set("Authorization", "Basic "+auth)
+// applyCustomFields fills the custom members of an issue from its raw fields.
+// Discovery failures only leave them empty, since the rest of the issue is still useful.
+func (client *JiraClient) applyCustomFields(ctx context.Context, issue *Issue) {
+	if issue.Fields.Raw == nil {
+		return
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
 
-	resp, err := client.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %v", err)
+	if id, err := client.FieldID(ctx, client.config.AcceptanceCriteriaField); err == nil {
+		if raw, ok := issue.Fields.Raw[id]; ok {
+			json.Unmarshal(raw, &issue.Fields.AcceptanceCriteria)
+		}
+	} else if *DEBUGflag {
+		fmt.Printf("Cannot resolve acceptance criteria field: %v\n", err)
 	}
 
-	return resp, nil
+	if id, err := client.FieldID(ctx, client.config.StoryPointsField); err == nil {
+		if raw, ok := issue.Fields.Raw[id]; ok {
+			json.Unmarshal(raw, &issue.Fields.StoryPoints)
+		}
+	} else if *DEBUGflag {
+		fmt.Printf("Cannot resolve story points field: %v\n", err)
+	}
 }
 
 // CreateIssue creates a new JIRA issue
-func (client *JiraClient) CreateIssue(issue *Issue) (*CreateIssueResponse, error) {
-	request := CreateIssueRequest{Fields: issue.Fields}
-	
-	resp, err := client.makeRequest("POST", "/rest/api/2/issue", request)
+func (client *JiraClient) CreateIssue(ctx context.Context, issue *Issue) (*CreateIssueResponse, error) {
+	fields, err := client.fieldsPayload(ctx, issue.Fields)
+	if err != nil {
+		return nil, err
+	}
+	request := CreateIssueRequest{Fields: fields}
+
+	resp, err := client.makeRequest(ctx, "POST", "/rest/api/2/issue", request)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to create issue: status %d, body: %s", resp.StatusCode, string(bodyBytes))
+		return nil, client.apiError(resp, "create issue")
 	}
 
 	var result CreateIssueResponse
@@ -164,30 +493,32 @@ func (client *JiraClient) CreateIssue(issue *Issue) (*CreateIssueResponse, error
 }
 
 // GetIssue retrieves a JIRA issue by ID or key
-func (client *JiraClient) GetIssue(issueIDOrKey string) (*Issue, error) {
+func (client *JiraClient) GetIssue(ctx context.Context, issueIDOrKey string) (*Issue, error) {
 	endpoint := fmt.Sprintf("/rest/api/2/issue/%s", issueIDOrKey)
 	
-	resp, err := client.makeRequest("GET", endpoint, nil)
+	resp, err := client.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get issue: status %d, body: %s", resp.StatusCode, string(bodyBytes))
+		return nil, client.apiError(resp, "get issue")
 	}
 
 	var issue Issue
 	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %v", err)
 	}
+	client.applyCustomFields(ctx, &issue)
 
 	return &issue, nil
 }
 
-func (client *JiraClient) UpdateAssignee(issueIDOrKey string, assignee *Assignee) error {
-	updateRequest := make(map[string]string)
+// UpdateAssignee assigns an issue, a nil assignee unassigns it
+func (client *JiraClient) UpdateAssignee(ctx context.Context, issueIDOrKey string, assignee *Assignee) error {
+	// a null name unassigns the issue
+	updateRequest := map[string]interface{}{"name": nil}
 	if assignee != nil {
 		updateRequest["name"] = assignee.Name
 	}
@@ -198,115 +529,48 @@ func (client *JiraClient) UpdateAssignee(issueIDOrKey string, assignee *Assignee
 
 	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/assignee", issueIDOrKey)
 
-	resp, err := client.makeRequest("PUT", endpoint, updateRequest)
+	resp, err := client.makeRequest(ctx, "PUT", endpoint, updateRequest)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update assignee: status %d, body: %s", resp.StatusCode, string(bodyBytes))
+		return client.apiError(resp, "update assignee")
 	}
 
 	return nil
 }
 
-// UpdateIssue updates an existing JIRA issue
-func (client *JiraClient) UpdateIssue(issueIDOrKey string, fields IssueFields) error {
-	updateFields := make(map[string]interface{})
-
-	if fields.Summary != "" {
-        updateFields["summary"] = fields.Summary
-    }
-    if fields.Description != "" {
-        updateFields["description"] = fields.Description
-    }
-    if fields.IssueType.ID != "" || fields.IssueType.Name != "" {
-        issueType := make(map[string]interface{})
-        if fields.IssueType.ID != "" {
-            issueType["id"] = fields.IssueType.ID
-        }
-        if fields.IssueType.Name != "" {
-            issueType["name"] = fields.IssueType.Name
-        }
-        updateFields["issuetype"] = issueType
-    }
-    if fields.Project.Key != "" || fields.Project.ID != "" {
-        project := make(map[string]interface{})
-        if fields.Project.Key != "" {
-            project["key"] = fields.Project.Key
-        }
-        if fields.Project.ID != "" {
-            project["id"] = fields.Project.ID
-        }
-        updateFields["project"] = project
-    }
-	if fields.Priority != nil {
-        priority := make(map[string]interface{})
-        if fields.Priority.ID != "" {
-            priority["id"] = fields.Priority.ID
-        }
-        if fields.Priority.Name != "" {
-            priority["name"] = fields.Priority.Name
-        }
-        updateFields["priority"] = priority
-    }
-	if fields.AcceptanceCriteria != "" {
-		updateFields["customfield_11028"] = fields.AcceptanceCriteria
-	}
-	if fields.StoryPoints >= 0.0 {
-		updateFields["customfield_10002"] = fields.StoryPoints
+// UpdateIssue applies an update to an existing JIRA issue. Every change is sent as an
+// update operation, so fields the update does not mention are left untouched.
+func (client *JiraClient) UpdateIssue(ctx context.Context, issueIDOrKey string, update IssueUpdate) error {
+	if update.IsZero() {
+		return nil
 	}
 
-	updateRequest := map[string]interface{}{
-        "fields": updateFields,
-    }
-
-	if *DEBUGflag {
-		fmt.Printf("UpdateIssue request: %+v\n", updateRequest)
-	}
-	
-	endpoint := fmt.Sprintf("/rest/api/2/issue/%s", issueIDOrKey)
-	
-	resp, err := client.makeRequest("PUT", endpoint, updateRequest)
+	operations, err := client.updateOperations(ctx, &update)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusNoContent {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update issue: status %d, body: %s", resp.StatusCode, string(bodyBytes))
-	}
 
-	return nil
+	return client.EditIssue(ctx, issueIDOrKey, operations)
 }
 
-func (client *JiraClient) GetTransitions(issueIDOrKey string) ([]Transition, error) {
-	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/transitions", issueIDOrKey)
+// GetTransitions returns the transitions available on an issue together with their screen fields
+func (client *JiraClient) GetTransitions(ctx context.Context, issueIDOrKey string) ([]Transition, error) {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/transitions?expand=transitions.fields", issueIDOrKey)
 
-	resp, err := client.makeRequest("GET", endpoint, nil)
+	resp, err := client.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get transitions: status %d, body: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	var result []Transition
-	var temp struct {
-		RawTransitions []map[string]interface{} `json:"transitions"`
+		return nil, client.apiError(resp, "get transitions")
 	}
 
-	// so this approach is a bit hacky but it works to get around the dynamic nature of the transitions
-	// ideally we would define a proper struct but the fields can vary widely
-	// so we just use a map[string]interface{} and extract the fields we care about
-	// see https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group
-
 	if *DEBUGflag {
 		fmt.Println("GetTransitions response:")
 		bodyBytes, _ := io.ReadAll(resp.Body)
@@ -315,26 +579,27 @@ func (client *JiraClient) GetTransitions(issueIDOrKey string) ([]Transition, err
 		resp.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&temp); err != nil {
+	var result struct {
+		Transitions []Transition `json:"transitions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %v", err)
 	}
 
-	if *DEBUGflag {
-		fmt.Printf("Decoded transitions: %+v\n", temp.RawTransitions)
-		fmt.Printf("\n\nchecking value: %s\n\n", temp.RawTransitions[0]["name"])
+	for _, t := range result.Transitions {
+		fillFieldIDs(t.Fields)
 	}
 
-	for _, t := range temp.RawTransitions {
-		result = append(result, Transition{
-			ID:   t["id"].(string),
-			Name: t["name"].(string),
-		})
+	if *DEBUGflag {
+		fmt.Printf("Decoded transitions: %+v\n", result.Transitions)
 	}
 
-	return result, nil
+	return result.Transitions, nil
 }
 
-func (client *JiraClient) DoTransition(issueIDOrKey , transitionID string) error {
+// DoTransition moves an issue through a transition. fields holds the screen fields
+// to set, keyed by field ID, and a non-empty comment is added along the way.
+func (client *JiraClient) DoTransition(ctx context.Context, issueIDOrKey, transitionID string, fields map[string]interface{}, comment string) error {
 	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/transitions", issueIDOrKey)
 
 	transitionRequest := map[string]interface{}{
@@ -342,38 +607,46 @@ func (client *JiraClient) DoTransition(issueIDOrKey , transitionID string) error
 			"id": transitionID,
 		},
 	}
+	if len(fields) > 0 {
+		transitionRequest["fields"] = fields
+	}
+	if comment != "" {
+		transitionRequest["update"] = map[string]interface{}{
+			"comment": []map[string]interface{}{
+				{"add": map[string]string{"body": comment}},
+			},
+		}
+	}
 
-	resp, err := client.makeRequest("POST", endpoint, transitionRequest)
+	if *DEBUGflag {
+		fmt.Printf("DoTransition request: %+v\n", transitionRequest)
+	}
+
+	resp, err := client.makeRequest(ctx, "POST", endpoint, transitionRequest)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to do transition: status %d, body: %s", resp.StatusCode, string(bodyBytes))
+		return client.apiError(resp, "do transition")
 	}
 
 	return nil
 }
 
-func (client *JiraClient) GetComments(issueIDOrKey string) ([]Comment, error) {
+// GetComments returns the comments of an issue
+func (client *JiraClient) GetComments(ctx context.Context, issueIDOrKey string) ([]Comment, error) {
 	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/comment", issueIDOrKey)
 
-	resp, err := client.makeRequest("GET", endpoint, nil)
+	resp, err := client.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get comments: status %d, body: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	var result []Comment
-	var temp struct {
-		Comments []map[string]interface{} `json:"comments"`
+		return nil, client.apiError(resp, "get comments")
 	}
 
 	if *DEBUGflag {
@@ -384,43 +657,73 @@ func (client *JiraClient) GetComments(issueIDOrKey string) ([]Comment, error) {
 		resp.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&temp); err != nil {
+	var result struct {
+		Comments []Comment `json:"comments"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %v", err)
 	}
 
-	/*
-	    "body": "Change 501887 had a related patch set uploaded by Sharma, Archit:\n[GTJ-691] enable QNX_IPC for ACF bindings\n\n[https://gitgerrit.asux.aptiv.com/c/ux/ispfw/core/+/501887|https://gitgerrit.asux.aptiv.com/c/ux/ispfw/core/+/501887]",
-		"updateAuthor": {
-			"self": "https://jiraprod.aptiv.com/rest/api/2/user?username=GID_GERRITJIRA",
-			"name": "GID_GERRITJIRA",
-			"key": "JIRAUSER176187",
-			"emailAddress": "",
-			"avatarUrls": {
-			"48x48": "https://jiraprod.aptiv.com/secure/useravatar?avatarId=11426",
-			"24x24": "https://jiraprod.aptiv.com/secure/useravatar?size=small&avatarId=11426",
-			"16x16": "https://jiraprod.aptiv.com/secure/useravatar?size=xsmall&avatarId=11426",
-			"32x32": "https://jiraprod.aptiv.com/secure/useravatar?size=medium&avatarId=11426"
-			},
-			"displayName": "GID_GERRITJIRA",
-			"active": true,
-			"timeZone": "Europe/Amsterdam"
-		},
-		"created": "2025-09-08T07:49:29.479+0200",
-		"updated": "2025-09-08T07:49:29.479+0200"
-	*/
+	return result.Comments, nil
+}
+
+// AddComment adds a comment to an issue and returns the created comment
+func (client *JiraClient) AddComment(ctx context.Context, issueIDOrKey, body string) (*Comment, error) {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/comment", issueIDOrKey)
+
+	resp, err := client.makeRequest(ctx, "POST", endpoint, map[string]string{"body": body})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, client.apiError(resp, "add comment")
+	}
+
+	var comment Comment
+	if err := json.NewDecoder(resp.Body).Decode(&comment); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
 
-	for _, c := range temp.Comments {
-		authorMeta := c["updateAuthor"].(map[string]interface{})
+	return &comment, nil
+}
+
+// UpdateComment replaces the body of an existing comment
+func (client *JiraClient) UpdateComment(ctx context.Context, issueIDOrKey, commentID, body string) (*Comment, error) {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/comment/%s", issueIDOrKey, commentID)
+
+	resp, err := client.makeRequest(ctx, "PUT", endpoint, map[string]string{"body": body})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.apiError(resp, "update comment")
+	}
 
-		result = append(result, Comment{
-			ID:		     c["id"].(string),
-			Body:        c["body"].(string),
-			Author:      authorMeta["displayName"].(string),
-			Created:     c["created"].(string),
-			LastUpdated: c["updated"].(string),
-			TimeZone:    authorMeta["timeZone"].(string),
-		})
+	var comment Comment
+	if err := json.NewDecoder(resp.Body).Decode(&comment); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
 	}
 
-	return result, nil
+	return &comment, nil
+}
+
+// DeleteComment deletes a comment from an issue
+func (client *JiraClient) DeleteComment(ctx context.Context, issueIDOrKey, commentID string) error {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/comment/%s", issueIDOrKey, commentID)
+
+	resp, err := client.makeRequest(ctx, "DELETE", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return client.apiError(resp, "delete comment")
+	}
+
+	return nil
 }
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// IssueLinkType represents a kind of link between two issues, e.g. "Blocks"
+type IssueLinkType struct {
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name"`
+	Inward  string `json:"inward,omitempty"`
+	Outward string `json:"outward,omitempty"`
+}
+
+// IssueLink represents a link as seen from one of its issues.
+// Exactly one of InwardIssue and OutwardIssue is set: an OutwardIssue means
+// "this issue <Type.Outward> OutwardIssue", an InwardIssue means
+// "this issue <Type.Inward> InwardIssue".
+type IssueLink struct {
+	ID           string        `json:"id,omitempty"`
+	Type         IssueLinkType `json:"type"`
+	InwardIssue  *Issue        `json:"inwardIssue,omitempty"`
+	OutwardIssue *Issue        `json:"outwardIssue,omitempty"`
+}
+
+// Relation returns the phrase describing the link from the point of view of its issue
+func (link IssueLink) Relation() string {
+	if link.OutwardIssue != nil {
+		return link.Type.Outward
+	}
+	return link.Type.Inward
+}
+
+// Other returns the issue on the other end of the link
+func (link IssueLink) Other() *Issue {
+	if link.OutwardIssue != nil {
+		return link.OutwardIssue
+	}
+	return link.InwardIssue
+}
+
+// GetIssueLinkTypes retrieves every link type configured on the instance
+func (client *JiraClient) GetIssueLinkTypes(ctx context.Context) ([]IssueLinkType, error) {
+	resp, err := client.makeRequest(ctx, "GET", "/rest/api/2/issueLinkType", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.apiError(resp, "get issue link types")
+	}
+
+	var result struct {
+		IssueLinkTypes []IssueLinkType `json:"issueLinkTypes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	return result.IssueLinkTypes, nil
+}
+
+// CreateIssueLink links two issues so that "from <outward> to" holds, e.g. from blocks to.
+// linkType is the link type name such as "Blocks".
+func (client *JiraClient) CreateIssueLink(ctx context.Context, from, to, linkType string) error {
+	// the REST API treats inwardIssue as the source of the link, so the
+	// outward description reads from inwardIssue to outwardIssue
+	linkRequest := map[string]interface{}{
+		"type":         map[string]string{"name": linkType},
+		"inwardIssue":  map[string]string{"key": from},
+		"outwardIssue": map[string]string{"key": to},
+	}
+
+	resp, err := client.makeRequest(ctx, "POST", "/rest/api/2/issueLink", linkRequest)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return client.apiError(resp, "create issue link")
+	}
+
+	return nil
+}
+
+// DeleteIssueLink deletes an issue link by ID
+func (client *JiraClient) DeleteIssueLink(ctx context.Context, linkID string) error {
+	endpoint := fmt.Sprintf("/rest/api/2/issueLink/%s", linkID)
+
+	resp, err := client.makeRequest(ctx, "DELETE", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return client.apiError(resp, "delete issue link")
+	}
+
+	return nil
+}
+
+// resolveLinkType finds the link type matching a name ("Blocks") or one of its
+// descriptions ("blocks", "is blocked by"). swap is true when the inward
+// description matched, meaning from and to must be exchanged for CreateIssueLink.
+func resolveLinkType(types []IssueLinkType, phrase string) (name string, swap bool, err error) {
+	for _, t := range types {
+		if strings.EqualFold(t.Name, phrase) || strings.EqualFold(t.Outward, phrase) {
+			return t.Name, false, nil
+		}
+	}
+	for _, t := range types {
+		if strings.EqualFold(t.Inward, phrase) {
+			return t.Name, true, nil
+		}
+	}
+
+	known := make([]string, 0, len(types))
+	for _, t := range types {
+		known = append(known, fmt.Sprintf("%q/%q", t.Outward, t.Inward))
+	}
+	return "", false, fmt.Errorf("unknown link type %q (known: %s)", phrase, strings.Join(known, ", "))
+}
+
+// linkIssues creates a link described by phrase, e.g. linkIssues(c, "GTJ-1", "is blocked by", "GTJ-2")
+func linkIssues(ctx context.Context, client *JiraClient, from, phrase, to string) error {
+	types, err := client.GetIssueLinkTypes(ctx)
+	if err != nil {
+		return err
+	}
+
+	name, swap, err := resolveLinkType(types, phrase)
+	if err != nil {
+		return err
+	}
+	if swap {
+		from, to = to, from
+	}
+
+	return client.CreateIssueLink(ctx, from, to, name)
+}
+
+// printIssueLinks prints the links of an issue grouped by relation
+func printIssueLinks(links []IssueLink) {
+	var relations []string
+	grouped := make(map[string][]IssueLink)
+	for _, link := range links {
+		relation := link.Relation()
+		if _, ok := grouped[relation]; !ok {
+			relations = append(relations, relation)
+		}
+		grouped[relation] = append(grouped[relation], link)
+	}
+
+	for _, relation := range relations {
+		fmt.Printf("  %s:\n", relation)
+		for _, link := range grouped[relation] {
+			other := link.Other()
+			if other == nil {
+				continue
+			}
+			status := ""
+			if other.Fields.Status != nil {
+				status = fmt.Sprintf(" [%s]", other.Fields.Status.Name)
+			}
+			fmt.Printf("    %s%s %s (link %s)\n", other.Key, status, other.Fields.Summary, link.ID)
+		}
+	}
+}
@@ -2,19 +2,166 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"syscall"
+	"text/tabwriter"
 )
 
 var DEBUGflag = flag.Bool("debug", false, "enable debugging messages for the app")
+var profileFlag = flag.String("profile", "", "use the named profile from the config file")
 
 func main() {
+	flag.Usage = func() { printUsage(os.Stderr) }
+	flag.Parse()
+
+	if flag.Arg(0) == "help" {
+		printUsage(os.Stdout)
+		return
+	}
+
+	// Run a single subcommand non-interactively when one is given.
+	// Ctrl-C cancels the in-flight request instead of killing the process mid-write.
+	if flag.NArg() > 0 {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		code := runCommand(ctx, flag.Args())
+		stop()
+		os.Exit(code)
+	}
+
+	config, client := connect()
+	if client == nil {
+		os.Exit(1)
+	}
+
+	// Start interactive CLI
+	if *DEBUGflag {
+		fmt.Println("JIRA Auto - Issue Management Tool (Running in Debug Mode)")
+	} else {
+		fmt.Println("JIRA Auto - Issue Management Tool")
+	}
+	fmt.Println("=================================")
+
+	// Verify the instance and the credentials before offering any action
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	user, err := client.CurrentUser(ctx)
+	stop()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if config.Profile != "" {
+		fmt.Printf("Profile: %s\n", config.Profile)
+	}
+	fmt.Printf("Connected to: %s\n", config.BaseURL)
+	fmt.Printf("Logged in as: %s (%s)\n", user.DisplayName, user.Name)
+	if user.EmailAddress != "" {
+		fmt.Printf("Email: %s\n", user.EmailAddress)
+	}
+	if user.TimeZone != "" {
+		fmt.Printf("Time zone: %s\n", user.TimeZone)
+	}
+	fmt.Printf("Authentication: %s\n\n", client.auth.Name())
+
+	stdin := &promptReader{r: os.Stdin}
+	scanner := bufio.NewScanner(stdin)
+
+	items := menuItems()
+
+	for {
+		fmt.Println("Available commands:")
+		for i, item := range items {
+			fmt.Printf("  %d. %s\n", i+1, item.label)
+		}
+		fmt.Printf("\nEnter your choice (1-%d): ", len(items))
+
+		if !scanner.Scan() {
+			fmt.Println()
+			return
+		}
+		choice, err := strconv.Atoi(strings.TrimSpace(scanner.Text()))
+
+		switch {
+		case err == nil && choice >= 1 && choice <= len(items) && items[choice-1].run == nil:
+			fmt.Println("Goodbye!")
+			return
+		case err == nil && choice >= 1 && choice <= len(items):
+			// Ctrl-C during a request cancels the action and returns to the menu
+			ctx, stop := stdin.interruptContext()
+			items[choice-1].run(ctx, client, scanner)
+			if ctx.Err() != nil {
+				fmt.Println("\nOperation cancelled.")
+			}
+			stop()
+		default:
+			fmt.Printf("Invalid choice. Please enter 1-%d.\n", len(items))
+		}
+
+		fmt.Println()
+	}
+}
+
+// promptReader wraps stdin and records whether the menu is waiting for input
+type promptReader struct {
+	r       io.Reader
+	waiting atomic.Bool
+}
+
+func (p *promptReader) Read(b []byte) (int, error) {
+	p.waiting.Store(true)
+	defer p.waiting.Store(false)
+	return p.r.Read(b)
+}
+
+// interruptContext returns a context cancelled by Ctrl-C while a request is running.
+// Ctrl-C at a prompt exits instead, as there is nothing to cancel and a blocked
+// read would otherwise make the user answer the remaining prompts first.
+func (p *promptReader) interruptContext() (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+
+	go func() {
+		for range interrupts {
+			if p.waiting.Load() {
+				fmt.Println()
+				os.Exit(exitInterrupted)
+			}
+			cancel()
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(interrupts)
+		close(interrupts)
+		cancel()
+	}
+}
+
+// connect loads the configuration and creates the JIRA client.
+// It explains what is missing and returns a nil client when the configuration is unusable.
+func connect() (*Config, *JiraClient) {
 	// Load configuration
-	config := LoadConfig()
+	config, err := LoadConfig(*profileFlag)
+	if err != nil {
+		var ierr *interruptedError
+		if errors.As(err, &ierr) {
+			fmt.Fprintf(os.Stderr, "Interrupted: %v\n", err)
+			os.Exit(exitInterrupted)
+		}
+		fmt.Printf("Error: %v\n", err)
+		return nil, nil
+	}
 	
 	// Validate configuration
 	if !config.Validate() {
@@ -34,70 +181,50 @@ func main() {
 		fmt.Println("  export JIRA_BASE_URL=https://yourcompany.atlassian.net")
 		fmt.Println("  export JIRA_USERNAME=your.email@company.com")
 		fmt.Println("  export JIRA_PAT=your-personal-access-token")
-		os.Exit(1)
+		fmt.Println("")
+		fmt.Println("Option 3 - Add a profile to the config file (see `jeera config list`)")
+		if config.Profile != "" {
+			fmt.Printf("\nProfile %q is missing some of these values.\n", config.Profile)
+		}
+		return nil, nil
 	}
 
 	// Create JIRA client
-	client := NewJiraClient(config)
-
-	flag.Parse()
-	// Start interactive CLI
-	if *DEBUGflag {
-		fmt.Println("JIRA Auto - Issue Management Tool (Running in Debug Mode)")
-	} else {
-		fmt.Println("JIRA Auto - Issue Management Tool")
-	}
-	fmt.Println("=================================")
-	fmt.Printf("Connected to: %s\n", config.BaseURL)
-	fmt.Printf("Username: %s\n", config.Username)
-	if config.UsePAT {
-		fmt.Printf("Authentication: Personal Access Token (Bearer)\n\n")
-	} else {
-		fmt.Printf("Authentication: API Token (Basic)\n\n")
-	}
-
-	scanner := bufio.NewScanner(os.Stdin)
+	return config, NewJiraClient(config)
+}
 
-	for {
-		fmt.Println("Available commands:")
-		fmt.Println("  1. Create issue")
-		fmt.Println("  2. Get issue")
-		fmt.Println("  3. Update issue")
-		fmt.Println("  4. Transition issue")
-		fmt.Println("  5. Get comments")
-		fmt.Println("  6. Bulk create issues")
-		fmt.Println("  7. Exit")
-		fmt.Print("\nEnter your choice (1-7): ")
-
-		scanner.Scan()
-		choice := strings.TrimSpace(scanner.Text())
-
-		switch choice {
-		case "1":
-			createIssueInteractive(client, scanner)
-		case "2":
-			getIssueInteractive(client, scanner)
-		case "3":
-			updateIssueInteractive(client, scanner)
-		case "4":
-			doTransitionInteractive(client, scanner)
-		case "5":
-			getCommentsInteractive(client, scanner)
-		case "6":
-			fmt.Println("not implemented yet")
-			// updateIssueInteractive(client, scanner)
-		case "7":
-			fmt.Println("Goodbye!")
-			return
-		default:
-			fmt.Println("Invalid choice. Please enter 1-4.")
-		}
+// menuItem is an entry of the interactive menu, a nil run exits
+type menuItem struct {
+	label string
+	run   func(ctx context.Context, client *JiraClient, scanner *bufio.Scanner)
+}
 
-		fmt.Println()
+// menuItems returns the entries of the interactive menu in display order.
+// Users type the numbers from memory, so new entries go at the end and the
+// original entries, Exit included, keep their numbers.
+func menuItems() []menuItem {
+	return []menuItem{
+		{"Create issue", createIssueInteractive},
+		{"Get issue", getIssueInteractive},
+		{"Update issue", updateIssueInteractive},
+		{"Transition issue", doTransitionInteractive},
+		{"Get comments", getCommentsInteractive},
+		{"Bulk create issues", bulkCreateInteractive},
+		{"Exit", nil},
+		{"Search issues (JQL)", searchIssuesInteractive},
+		{"Add comment", addCommentInteractive},
+		{"Edit comment", editCommentInteractive},
+		{"Delete comment", deleteCommentInteractive},
+		{"Link issues", linkIssuesInteractive},
+		{"Remove issue link", deleteIssueLinkInteractive},
+		{"Set field by name", setFieldInteractive},
+		{"List fields", listFieldsInteractive},
+		{"Move issue to status", moveIssueInteractive},
+		{"Log work", logWorkInteractive},
 	}
 }
 
-func createIssueInteractive(client *JiraClient, scanner *bufio.Scanner) {
+func createIssueInteractive(ctx context.Context, client *JiraClient, scanner *bufio.Scanner) {
 	fmt.Println("\n--- Create New Issue ---")
 	
 	fmt.Print("Project Key: ")
@@ -129,7 +256,7 @@ func createIssueInteractive(client *JiraClient, scanner *bufio.Scanner) {
 		},
 	}
 
-	result, err := client.CreateIssue(issue)
+	result, err := client.CreateIssue(ctx, issue)
 	if err != nil {
 		log.Printf("Error creating issue: %v", err)
 		return
@@ -138,37 +265,253 @@ func createIssueInteractive(client *JiraClient, scanner *bufio.Scanner) {
 	fmt.Printf("✅ Issue created successfully!\n")
 	fmt.Printf("Key: %s\n", result.Key)
 	fmt.Printf("ID: %s\n", result.ID)
+	for _, err := range client.addAutoWatchers(ctx, result.Key, issueType) {
+		log.Printf("Warning: %v", err)
+	}
 }
 
-func getIssueInteractive(client *JiraClient, scanner *bufio.Scanner) {
+func getIssueInteractive(ctx context.Context, client *JiraClient, scanner *bufio.Scanner) {
 	fmt.Println("\n--- Get Issue ---")
 	
 	fmt.Print("Issue ID or Key: ")
 	scanner.Scan()
 	issueIDOrKey := strings.TrimSpace(scanner.Text())
 
-	issue, err := client.GetIssue(issueIDOrKey)
+	issue, err := client.GetIssue(ctx, issueIDOrKey)
 	if err != nil {
 		log.Printf("Error getting issue: %v", err)
 		return
 	}
 
 	fmt.Printf("✅ Issue retrieved successfully!\n")
+	printIssue(issue)
+}
+
+// printIssue prints the details of an issue
+func printIssue(issue *Issue) {
 	fmt.Printf("Key: %s\n", issue.Key)
 	fmt.Printf("ID: %s\n", issue.ID)
 	fmt.Printf("Summary: %s\n", issue.Fields.Summary)
 	fmt.Printf("Description: %s\n", issue.Fields.Description)
-	fmt.Printf("Issue Type: %s\n", issue.Fields.IssueType.Name)
-	fmt.Printf("Assignee: %s\n", issue.Fields.Assignee.DisplayName)
+	if issue.Fields.IssueType != nil {
+		fmt.Printf("Issue Type: %s\n", issue.Fields.IssueType.Name)
+	}
+	if issue.Fields.Assignee != nil {
+		fmt.Printf("Assignee: %s\n", issue.Fields.Assignee.DisplayName)
+	} else {
+		fmt.Printf("Assignee: Unassigned\n")
+	}
 	if issue.Fields.Status != nil {
 		fmt.Printf("Status: %s\n", issue.Fields.Status.Name)
 	}
 	if issue.Fields.Priority != nil {
 		fmt.Printf("Priority: %s\n", issue.Fields.Priority.Name)
 	}
+	if len(issue.Fields.Labels) > 0 {
+		fmt.Printf("Labels: %s\n", strings.Join(issue.Fields.Labels, ", "))
+	}
+	if len(issue.Fields.Components) > 0 {
+		fmt.Printf("Components: %s\n", strings.Join(componentNames(issue.Fields.Components), ", "))
+	}
+	if len(issue.Fields.FixVersions) > 0 {
+		fmt.Printf("Fix Versions: %s\n", strings.Join(versionNames(issue.Fields.FixVersions), ", "))
+	}
+	if len(issue.Fields.Versions) > 0 {
+		fmt.Printf("Affects Versions: %s\n", strings.Join(versionNames(issue.Fields.Versions), ", "))
+	}
+	if len(issue.Fields.IssueLinks) > 0 {
+		fmt.Printf("Links:\n")
+		printIssueLinks(issue.Fields.IssueLinks)
+	}
+}
+
+func linkIssuesInteractive(ctx context.Context, client *JiraClient, scanner *bufio.Scanner) {
+	fmt.Println("\n--- Link Issues ---")
+
+	fmt.Print("From Issue Key: ")
+	scanner.Scan()
+	from := strings.TrimSpace(scanner.Text())
+
+	types, err := client.GetIssueLinkTypes(ctx)
+	if err != nil {
+		log.Printf("Error fetching link types: %v", err)
+		return
+	}
+	if len(types) == 0 {
+		fmt.Println("No link types configured on this instance.")
+		return
+	}
+
+	// offer both directions of every link type
+	type relation struct {
+		phrase string
+		name   string
+		swap   bool
+	}
+	var relations []relation
+	for _, t := range types {
+		relations = append(relations, relation{t.Outward, t.Name, false})
+		if t.Inward != t.Outward {
+			relations = append(relations, relation{t.Inward, t.Name, true})
+		}
+	}
+
+	fmt.Println("Available Relations:")
+	for i, r := range relations {
+		fmt.Printf("  %d. %s %s ...\n", i+1, from, r.phrase)
+	}
+
+	fmt.Print("\nSelect relation number: ")
+	scanner.Scan()
+	choice, err := strconv.Atoi(strings.TrimSpace(scanner.Text()))
+	if err != nil || choice < 1 || choice > len(relations) {
+		fmt.Println("Invalid choice.")
+		return
+	}
+	selected := relations[choice-1]
+
+	fmt.Print("To Issue Key: ")
+	scanner.Scan()
+	to := strings.TrimSpace(scanner.Text())
+
+	source, target := from, to
+	if selected.swap {
+		source, target = to, from
+	}
+	if err := client.CreateIssueLink(ctx, source, target, selected.name); err != nil {
+		log.Printf("Error linking issues: %v", err)
+		return
+	}
+
+	fmt.Printf("✅ %s %s %s linked successfully!\n", from, selected.phrase, to)
+}
+
+func deleteIssueLinkInteractive(ctx context.Context, client *JiraClient, scanner *bufio.Scanner) {
+	fmt.Println("\n--- Remove Issue Link ---")
+
+	fmt.Print("Issue ID or Key: ")
+	scanner.Scan()
+	issueIDOrKey := strings.TrimSpace(scanner.Text())
+
+	issue, err := client.GetIssue(ctx, issueIDOrKey)
+	if err != nil {
+		log.Printf("Error getting issue: %v", err)
+		return
+	}
+
+	links := issue.Fields.IssueLinks
+	if len(links) == 0 {
+		fmt.Println("This issue has no links.")
+		return
+	}
+
+	fmt.Println("Links:")
+	for i, link := range links {
+		key := ""
+		if other := link.Other(); other != nil {
+			key = other.Key
+		}
+		fmt.Printf("  %d. %s %s\n", i+1, link.Relation(), key)
+	}
+
+	fmt.Print("\nSelect link number to remove: ")
+	scanner.Scan()
+	choice, err := strconv.Atoi(strings.TrimSpace(scanner.Text()))
+	if err != nil || choice < 1 || choice > len(links) {
+		fmt.Println("Invalid choice.")
+		return
+	}
+
+	if err := client.DeleteIssueLink(ctx, links[choice-1].ID); err != nil {
+		log.Printf("Error removing link: %v", err)
+		return
+	}
+
+	fmt.Printf("✅ Link removed successfully!\n")
+}
+
+// printIssueTable prints one line per issue with key, status, assignee and summary
+func printIssueTable(issues []Issue) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tSTATUS\tASSIGNEE\tSUMMARY")
+	for _, issue := range issues {
+		assignee := issue.Fields.AssigneeName()
+		if assignee == "" {
+			assignee = "Unassigned"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", issue.Key, issue.Fields.StatusName(), assignee, issue.Fields.Summary)
+	}
+	w.Flush()
+}
+
+func searchIssuesInteractive(ctx context.Context, client *JiraClient, scanner *bufio.Scanner) {
+	fmt.Println("\n--- Search Issues ---")
+
+	fmt.Print("JQL: ")
+	scanner.Scan()
+	jql := strings.TrimSpace(scanner.Text())
+	if jql == "" {
+		fmt.Println("No query specified.")
+		return
+	}
+
+	issues, err := client.SearchIssues(ctx, jql, searchTableFields, nil)
+	if err != nil {
+		log.Printf("Error searching issues: %v", err)
+		return
+	}
+
+	if len(issues) == 0 {
+		fmt.Println("No issues found.")
+		return
+	}
+
+	fmt.Printf("✅ Found %d issue(s)\n\n", len(issues))
+	printIssueTable(issues)
 }
 
-func updateIssueInteractive(client *JiraClient, scanner *bufio.Scanner) {
+func bulkCreateInteractive(ctx context.Context, client *JiraClient, scanner *bufio.Scanner) {
+	fmt.Println("\n--- Bulk Create Issues ---")
+
+	fmt.Print("CSV file: ")
+	scanner.Scan()
+	path := strings.TrimSpace(scanner.Text())
+	if path == "" {
+		fmt.Println("No file specified.")
+		return
+	}
+
+	resultPath := bulkResultPath(path)
+	summary, err := bulkCreateFromFile(ctx, client, path, resultPath)
+	if err != nil {
+		log.Printf("Error creating issues: %v", err)
+		if summary == nil {
+			return
+		}
+	}
+
+	if !summary.Written {
+		fmt.Printf("⚠️  Created %d of %d issue(s), %d failed, %d not sent\n",
+			summary.Created, summary.Total, summary.Failed, summary.NotSent)
+		return
+	}
+	if summary.Created < summary.Total {
+		fmt.Printf("⚠️  Created %d of %d issue(s), %d failed, %d not sent, see %s\n",
+			summary.Created, summary.Total, summary.Failed, summary.NotSent, resultPath)
+		return
+	}
+	if summary.WatchFailed > 0 {
+		fmt.Printf("⚠️  Issues created, but auto watchers could not be added to %d of them, see %s\n",
+			summary.WatchFailed, resultPath)
+		return
+	}
+	fmt.Printf("✅ Issues created successfully! Results written to %s\n", resultPath)
+}
+
+// clearInput entered at a prompt empties the field instead of keeping it
+const clearInput = "-"
+
+func updateIssueInteractive(ctx context.Context, client *JiraClient, scanner *bufio.Scanner) {
 	fmt.Println("\n--- Update Issue ---")
 
 	fmt.Print("Issue ID or Key: ")
@@ -179,67 +522,143 @@ func updateIssueInteractive(client *JiraClient, scanner *bufio.Scanner) {
 	scanner.Scan()
 	summary := strings.TrimSpace(scanner.Text())
 
-	fmt.Print("New Description (leave empty to keep current): ")
+	fmt.Print("New Description (leave empty to keep current, - to clear): ")
 	scanner.Scan()
 	description := strings.TrimSpace(scanner.Text())
 
-	fmt.Print("New Acceptance Criteria (leave empty to keep current): ")
+	fmt.Print("New Acceptance Criteria (leave empty to keep current, - to clear): ")
 	scanner.Scan()
 	acceptanceCriteria := strings.TrimSpace(scanner.Text())
 
-	fmt.Print("New Story Points (leave empty to keep current): ")
+	fmt.Print("New Story Points (leave empty to keep current, - to clear): ")
 	scanner.Scan()
 	// storyPoints should be an integer
 	storyPoints := strings.TrimSpace(scanner.Text())
 
-	fmt.Print("New Assignee ID (leave empty to keep current): ")
+	fmt.Print("New Assignee ID (leave empty to keep current, - to clear): ")
 	scanner.Scan()
 	assignee := strings.TrimSpace(scanner.Text())
 
-	// Build update fields
-	fields := IssueFields{}
+	// Build the update, only what was entered is changed
+	update := IssueUpdate{}
 	if summary != "" {
-		fields.Summary = summary
+		update.Summary = &summary
 	}
-	if description != "" {
-		fields.Description = description
+	switch description {
+	case "":
+	case clearInput:
+		update.Clear = append(update.Clear, "description")
+	default:
+		update.Description = &description
 	}
-	if acceptanceCriteria != "" {
-		fields.AcceptanceCriteria = acceptanceCriteria
+	switch acceptanceCriteria {
+	case "":
+	case clearInput:
+		update.Clear = append(update.Clear, client.config.AcceptanceCriteriaField)
+	default:
+		update.AcceptanceCriteria = &acceptanceCriteria
 	}
-	if storyPoints != "" {
-		if sp, err := strconv.ParseFloat(storyPoints, 32); err != nil {
+	if storyPoints == clearInput {
+		update.Clear = append(update.Clear, client.config.StoryPointsField)
+	} else if storyPoints != "" {
+		sp, err := strconv.ParseFloat(storyPoints, 32)
+		if err != nil {
 			fmt.Printf("Invalid story points value: %v\n", err)
-		} else {
-			fields.StoryPoints = float32(sp)
+			return
+		}
+		points := float32(sp)
+		update.StoryPoints = &points
+	}
+	if update.IsZero() && assignee == "" {
+		fmt.Println("No changes specified.")
+		return
+	}
+
+	// the fields go first, so a refused update leaves the assignee alone
+	if !update.IsZero() {
+		if err := client.UpdateIssue(ctx, issueIDOrKey, update); err != nil {
+			log.Printf("Error updating issue: %v", err)
+			return
 		}
+		fmt.Printf("✅ Issue %s updated successfully!\n", issueIDOrKey)
 	}
-	if assignee != "" {
+
+	if assignee == clearInput {
+		if err := client.UpdateAssignee(ctx, issueIDOrKey, nil); err != nil {
+			log.Printf("Error updating assignee: %v", err)
+			return
+		}
+		fmt.Printf("✅ Issue %s unassigned successfully!\n", issueIDOrKey)
+	} else if assignee != "" {
 		tmpAssignee := &Assignee{}
 		tmpAssignee.Name = assignee
 
-		if err := client.UpdateAssignee(issueIDOrKey, tmpAssignee); err != nil {
+		if err := client.UpdateAssignee(ctx, issueIDOrKey, tmpAssignee); err != nil {
 			log.Printf("Error updating assignee: %v", err)
 			return
 		}
 		fmt.Printf("✅ Issue %s assigned to %s successfully!\n", issueIDOrKey, assignee)
 	}
+}
 
-	if fields.Summary == "" && fields.Description == "" && fields.AcceptanceCriteria == "" && fields.StoryPoints <= 0.0 {
-		fmt.Println("No changes specified.")
+func setFieldInteractive(ctx context.Context, client *JiraClient, scanner *bufio.Scanner) {
+	fmt.Println("\n--- Set Field ---")
+
+	fmt.Print("Issue ID or Key: ")
+	scanner.Scan()
+	issueIDOrKey := strings.TrimSpace(scanner.Text())
+
+	fmt.Print("Field name (e.g., Story Points): ")
+	scanner.Scan()
+	name := strings.TrimSpace(scanner.Text())
+
+	field, err := client.LookupFieldForIssue(ctx, issueIDOrKey, name)
+	if err != nil {
+		log.Printf("Error resolving field: %v", err)
+		return
+	}
+
+	if len(field.AllowedValues) > 0 {
+		fmt.Println("Allowed values:")
+		for _, v := range field.AllowedValues {
+			fmt.Printf("  %s\n", formatFieldValue(v))
+		}
+	}
+	fmt.Printf("New value for %s (%s): ", field.Name, schemaString(field.Schema))
+	scanner.Scan()
+	input := strings.TrimSpace(scanner.Text())
+
+	value, err := metaFieldValue(*field, input)
+	if err != nil {
+		fmt.Printf("Invalid value: %v\n", err)
+		return
+	}
+
+	if err := client.UpdateFields(ctx, issueIDOrKey, map[string]interface{}{field.FieldID: value}); err != nil {
+		log.Printf("Error updating field: %v", err)
 		return
 	}
 
-	err := client.UpdateIssue(issueIDOrKey, fields)
+	fmt.Printf("✅ %s of %s updated successfully!\n", field.Name, issueIDOrKey)
+}
+
+func listFieldsInteractive(ctx context.Context, client *JiraClient, scanner *bufio.Scanner) {
+	fmt.Println("\n--- List Fields ---")
+
+	fmt.Print("Filter (leave empty for all): ")
+	scanner.Scan()
+	filter := strings.TrimSpace(scanner.Text())
+
+	fields, err := client.fields(ctx, false)
 	if err != nil {
-		log.Printf("Error updating issue: %v", err)
+		log.Printf("Error fetching fields: %v", err)
 		return
 	}
 
-	fmt.Printf("✅ Issue %s updated successfully!\n", issueIDOrKey)
+	printFields(fields, filter)
 }
 
-func doTransitionInteractive(client *JiraClient, scanner *bufio.Scanner) {
+func doTransitionInteractive(ctx context.Context, client *JiraClient, scanner *bufio.Scanner) {
 	fmt.Println("\n--- Transition Issue ---")
 
 	fmt.Print("Issue ID or Key: ")
@@ -247,7 +666,7 @@ func doTransitionInteractive(client *JiraClient, scanner *bufio.Scanner) {
 	issueIDOrKey := strings.TrimSpace(scanner.Text())
 
 	// Fetch available transitions
-	transitions, err := client.GetTransitions(issueIDOrKey)
+	transitions, err := client.GetTransitions(ctx, issueIDOrKey)
 	if err != nil {
 		log.Printf("Error fetching transitions: %v", err)
 		return
@@ -258,10 +677,7 @@ func doTransitionInteractive(client *JiraClient, scanner *bufio.Scanner) {
 		return
 	}
 
-	fmt.Println("Available Transitions:")
-	for i, t := range transitions {
-		fmt.Printf("  %d. %s (ID: %s)\n", i+1, t.Name, t.ID)
-	}
+	printTransitions(transitions)
 
 	fmt.Print("\nSelect transition number: ")
 	scanner.Scan()
@@ -275,7 +691,23 @@ func doTransitionInteractive(client *JiraClient, scanner *bufio.Scanner) {
 
 	selectedTransition := transitions[choice-1]
 
-	err = client.DoTransition(issueIDOrKey, selectedTransition.ID)
+	// ask for the screen fields the transition requires and the issue does not have yet
+	missing, err := client.unsetRequiredFields(ctx, issueIDOrKey, &selectedTransition, nil)
+	if err != nil {
+		log.Printf("Error checking required fields: %v", err)
+		return
+	}
+	fields, err := promptTransitionFields(scanner, missing)
+	if err != nil {
+		log.Printf("Error reading fields: %v", err)
+		return
+	}
+
+	fmt.Print("Comment (optional): ")
+	scanner.Scan()
+	comment := strings.TrimSpace(scanner.Text())
+
+	err = client.DoTransition(ctx, issueIDOrKey, selectedTransition.ID, fields, comment)
 	if err != nil {
 		log.Printf("Error performing transition: %v", err)
 		return
@@ -284,14 +716,63 @@ func doTransitionInteractive(client *JiraClient, scanner *bufio.Scanner) {
 	fmt.Printf("✅ Issue %s transitioned to '%s' successfully!\n", issueIDOrKey, selectedTransition.Name)
 }
 
-func getCommentsInteractive(client *JiraClient, scanner *bufio.Scanner) {
+func moveIssueInteractive(ctx context.Context, client *JiraClient, scanner *bufio.Scanner) {
+	fmt.Println("\n--- Move Issue to Status ---")
+
+	fmt.Print("Issue ID or Key: ")
+	scanner.Scan()
+	issueIDOrKey := strings.TrimSpace(scanner.Text())
+
+	fmt.Print("Target status: ")
+	scanner.Scan()
+	target := strings.TrimSpace(scanner.Text())
+
+	issue, err := client.GetIssue(ctx, issueIDOrKey)
+	if err != nil {
+		log.Printf("Error fetching issue: %v", err)
+		return
+	}
+
+	path, err := client.workflowPath(ctx, issue, target)
+	if err != nil {
+		log.Printf("Error finding a path: %v", err)
+		return
+	}
+	if len(path) == 0 {
+		fmt.Printf("Issue %s is already in '%s'.\n", issue.Key, issue.Fields.StatusName())
+		return
+	}
+
+	fmt.Println("Transitions to perform:")
+	for i, h := range path {
+		fmt.Printf("  %d. %s\n", i+1, h)
+	}
+	fmt.Print("Proceed? (y/N): ")
+	scanner.Scan()
+	if !strings.EqualFold(strings.TrimSpace(scanner.Text()), "y") {
+		fmt.Println("Move cancelled.")
+		return
+	}
+
+	err = client.moveIssue(ctx, issue.Key, path, nil, func(step int, h hop) {
+		fmt.Printf("  ✓ %s\n", h)
+	})
+	if err != nil {
+		log.Printf("Error moving issue: %v", err)
+		return
+	}
+
+	fmt.Printf("✅ Issue %s moved to '%s' successfully!\n", issue.Key, path[len(path)-1].Transition.To.Name)
+}
+
+func getCommentsInteractive(ctx context.Context, client *JiraClient, scanner *bufio.Scanner) {
 	fmt.Println("\n--- Get Comments ---")
 
 	fmt.Print("Issue ID or Key: ")
 	scanner.Scan()
 	issueIDOrKey := strings.TrimSpace(scanner.Text())
 
-	comments, err := client.GetComments(issueIDOrKey)
+	comments, err := client.GetComments(ctx, issueIDOrKey)
 	if err != nil {
 		log.Printf("Error getting comments: %v", err)
 		return
@@ -303,12 +784,184 @@ func getCommentsInteractive(client *JiraClient, scanner *bufio.Scanner) {
 	}
 
 	fmt.Printf("✅ Comments retrieved successfully! Total: %d\n", len(comments))
+	printComments(comments)
+}
+
+func addCommentInteractive(ctx context.Context, client *JiraClient, scanner *bufio.Scanner) {
+	fmt.Println("\n--- Add Comment ---")
+
+	fmt.Print("Issue ID or Key: ")
+	scanner.Scan()
+	issueIDOrKey := strings.TrimSpace(scanner.Text())
+
+	fmt.Println("Comment body:")
+	body, err := readMultiline(scanner, "")
+	if err != nil {
+		log.Printf("Error reading comment: %v", err)
+		return
+	}
+	if body == "" {
+		fmt.Println("Empty comment, nothing to add.")
+		return
+	}
+
+	comment, err := client.AddComment(ctx, issueIDOrKey, body)
+	if err != nil {
+		log.Printf("Error adding comment: %v", err)
+		return
+	}
+
+	fmt.Printf("✅ Comment %s added to %s successfully!\n", comment.ID, issueIDOrKey)
+}
+
+func editCommentInteractive(ctx context.Context, client *JiraClient, scanner *bufio.Scanner) {
+	fmt.Println("\n--- Edit Comment ---")
+
+	fmt.Print("Issue ID or Key: ")
+	scanner.Scan()
+	issueIDOrKey := strings.TrimSpace(scanner.Text())
+
+	fmt.Print("Comment ID: ")
+	scanner.Scan()
+	commentID := strings.TrimSpace(scanner.Text())
+
+	current, err := findComment(ctx, client, issueIDOrKey, commentID)
+	if err != nil {
+		log.Printf("Error getting comment: %v", err)
+		return
+	}
+
+	fmt.Printf("Current body:\n%s\n\nNew body:\n", current.Body)
+	body, err := readMultiline(scanner, current.Body)
+	if err != nil {
+		log.Printf("Error reading comment: %v", err)
+		return
+	}
+	if body == "" || body == current.Body {
+		fmt.Println("No changes specified.")
+		return
+	}
+
+	if _, err := client.UpdateComment(ctx, issueIDOrKey, commentID, body); err != nil {
+		log.Printf("Error updating comment: %v", err)
+		return
+	}
+
+	fmt.Printf("✅ Comment %s updated successfully!\n", commentID)
+}
+
+func deleteCommentInteractive(ctx context.Context, client *JiraClient, scanner *bufio.Scanner) {
+	fmt.Println("\n--- Delete Comment ---")
+
+	fmt.Print("Issue ID or Key: ")
+	scanner.Scan()
+	issueIDOrKey := strings.TrimSpace(scanner.Text())
+
+	fmt.Print("Comment ID: ")
+	scanner.Scan()
+	commentID := strings.TrimSpace(scanner.Text())
+
+	fmt.Printf("Delete comment %s from %s? (y/N): ", commentID, issueIDOrKey)
+	scanner.Scan()
+	if !strings.EqualFold(strings.TrimSpace(scanner.Text()), "y") {
+		fmt.Println("Cancelled.")
+		return
+	}
+
+	if err := client.DeleteComment(ctx, issueIDOrKey, commentID); err != nil {
+		log.Printf("Error deleting comment: %v", err)
+		return
+	}
+
+	fmt.Printf("✅ Comment %s deleted successfully!\n", commentID)
+}
+
+func logWorkInteractive(ctx context.Context, client *JiraClient, scanner *bufio.Scanner) {
+	fmt.Println("\n--- Log Work ---")
+
+	fmt.Print("Issue ID or Key: ")
+	scanner.Scan()
+	issueIDOrKey := strings.TrimSpace(scanner.Text())
+
+	worklogs, err := client.GetWorklogs(ctx, issueIDOrKey)
+	if err != nil {
+		log.Printf("Error fetching worklogs: %v", err)
+		return
+	}
+	printWorklogs(worklogs)
+
+	fmt.Print("\nTime spent (e.g. 2h, 1h 30m, 1d): ")
+	scanner.Scan()
+	seconds, err := parseDuration(strings.TrimSpace(scanner.Text()))
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return
+	}
+
+	fmt.Print("Started (YYYY-MM-DD[ HH:MM], empty for now): ")
+	scanner.Scan()
+	started, err := parseStarted(strings.TrimSpace(scanner.Text()))
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return
+	}
+
+	fmt.Print("Comment (optional): ")
+	scanner.Scan()
+	comment := strings.TrimSpace(scanner.Text())
+
+	worklog, err := client.AddWorklog(ctx, issueIDOrKey, started, seconds, comment)
+	if err != nil {
+		log.Printf("Error logging work: %v", err)
+		return
+	}
+
+	fmt.Printf("✅ Logged %s on %s successfully! (worklog %s)\n", formatDuration(worklog.TimeSpentSeconds), issueIDOrKey, worklog.ID)
+}
+
+// findComment looks up a single comment of an issue by ID
+func findComment(ctx context.Context, client *JiraClient, issueIDOrKey, commentID string) (*Comment, error) {
+	comments, err := client.GetComments(ctx, issueIDOrKey)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range comments {
+		if comments[i].ID == commentID {
+			return &comments[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("comment %s not found on %s", commentID, issueIDOrKey)
+}
+
+// printTransitions prints the numbered list of available transitions
+func printTransitions(transitions []Transition) {
+	if len(transitions) == 0 {
+		fmt.Println("No transitions available for this issue.")
+		return
+	}
+	fmt.Println("Available Transitions:")
+	for i, t := range transitions {
+		if t.To != nil {
+			fmt.Printf("  %d. %s → %s (ID: %s)\n", i+1, t.Name, t.To.Name, t.ID)
+			continue
+		}
+		fmt.Printf("  %d. %s (ID: %s)\n", i+1, t.Name, t.ID)
+	}
+}
+
+// printComments prints every comment with its metadata and body
+func printComments(comments []Comment) {
 	for _, c := range comments {
 		fmt.Printf("\nCommentID %s\n", c.ID)
-		fmt.Printf("Author: %s\n", c.Author)
-		fmt.Printf("Created: %s\n", c.Created)
-		fmt.Printf("Last Updated: %s\n", c.LastUpdated)
+		fmt.Printf("Author: %s\n", c.AuthorName())
+		fmt.Printf("Created: %s\n", c.Created.Format(jiraTimeLayout))
+		fmt.Printf("Last Updated: %s\n", c.Updated.Format(jiraTimeLayout))
 		//          Last Updated: 2025-09-08T11:18:04.666+0200 -> longest field
+		if c.Visibility != nil {
+			fmt.Printf("Visible to: %s %s\n", c.Visibility.Type, c.Visibility.Value)
+		}
 		fmt.Printf("------------------------------------------\n%s\n\n", c.Body)
 	}
 }
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// hop is one transition on the way to a target status
+type hop struct {
+	From       string
+	Transition Transition
+}
+
+// String describes the hop as "Start (Open → In Progress)"
+func (h hop) String() string {
+	return fmt.Sprintf("%s (%s → %s)", h.Transition.Name, h.From, h.Transition.To.Name)
+}
+
+// Limits of the workflow exploration of workflowPath. Exploring a status costs a
+// search and a transitions request.
+const (
+	maxMoveHops     = 8
+	maxMoveExplored = 10
+)
+
+// workflowPath finds the shortest sequence of transitions taking issue to the status named target.
+// JIRA only lists the transitions out of an issue's current status, so the workflow is
+// explored through other issues of the same project and type sitting in each status
+// along the way. The path is empty when the issue already has the target status.
+func (client *JiraClient) workflowPath(ctx context.Context, issue *Issue, target string) ([]hop, error) {
+	start := issue.Fields.StatusName()
+	if strings.EqualFold(start, target) {
+		return nil, nil
+	}
+
+	own, err := client.GetTransitions(ctx, issue.Key)
+	if err != nil {
+		return nil, err
+	}
+	var global []Transition
+	for _, t := range own {
+		if t.IsGlobal {
+			global = append(global, t)
+		}
+	}
+
+	path, unexplored, err := shortestPath(start, target, global, func(status string) ([]Transition, bool, error) {
+		if strings.EqualFold(status, start) {
+			return own, true, nil
+		}
+		return client.sampleTransitions(ctx, issue, status)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if path == nil {
+		msg := fmt.Sprintf("no path from '%s' to '%s' found in the workflow of %s", start, target, issue.Key)
+		if len(unexplored) > 0 {
+			msg += ", could not explore " + strings.Join(unexplored, ", ")
+		}
+		return nil, errors.New(msg)
+	}
+	return path, nil
+}
+
+// shortestPath searches the workflow breadth-first from start for target. transitionsOf
+// returns the transitions out of a status, or false when the status cannot be explored;
+// the global transitions, which every status has, are used for those instead. Exploration
+// stops at maxMoveHops and maxMoveExplored. Without a path, unexplored describes the
+// statuses that could not be explored and why.
+func shortestPath(start, target string, global []Transition, transitionsOf func(status string) ([]Transition, bool, error)) (path []hop, unexplored []string, err error) {
+	// statuses are compared by name, case-insensitively, like the rest of the CLI
+	previous := map[string]hop{}
+	visited := map[string]bool{strings.ToLower(start): true}
+	depth := map[string]int{strings.ToLower(start): 0}
+	queue := []string{start}
+	explored := 0
+
+	for len(queue) > 0 {
+		status := queue[0]
+		queue = queue[1:]
+
+		if depth[strings.ToLower(status)] >= maxMoveHops {
+			unexplored = append(unexplored, fmt.Sprintf("'%s' (more than %d transitions away)", status, maxMoveHops))
+			continue
+		}
+		if explored >= maxMoveExplored {
+			unexplored = append(unexplored, fmt.Sprintf("'%s' (exploration limit of %d statuses reached)", status, maxMoveExplored))
+			continue
+		}
+		explored++
+
+		transitions, ok, err := transitionsOf(status)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			unexplored = append(unexplored, fmt.Sprintf("'%s' (no issue in it to explore from)", status))
+			transitions = global
+		}
+
+		for _, t := range transitions {
+			if t.To == nil {
+				continue
+			}
+			next := strings.ToLower(t.To.Name)
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			depth[next] = depth[strings.ToLower(status)] + 1
+			previous[next] = hop{From: status, Transition: t}
+
+			if strings.EqualFold(t.To.Name, target) {
+				return pathTo(previous, next, start), nil, nil
+			}
+			queue = append(queue, t.To.Name)
+		}
+	}
+
+	return nil, unexplored, nil
+}
+
+// pathTo walks the hops recorded by shortestPath back from status to start
+func pathTo(previous map[string]hop, status, start string) []hop {
+	var path []hop
+	for !strings.EqualFold(status, start) {
+		h := previous[status]
+		path = append([]hop{h}, path...)
+		status = strings.ToLower(h.From)
+	}
+	return path
+}
+
+// sampleTransitions returns the transitions out of status in the workflow of issue,
+// taken from an issue of the same project and type in that status. It reports false
+// when there is no such issue.
+func (client *JiraClient) sampleTransitions(ctx context.Context, issue *Issue, status string) ([]Transition, bool, error) {
+	if issue.Fields.Project == nil || issue.Fields.IssueType == nil {
+		return nil, false, fmt.Errorf("project and issue type of %s are unknown", issue.Key)
+	}
+
+	jql := fmt.Sprintf("project = %s AND issuetype = %s AND status = %s ORDER BY updated DESC",
+		quoteJQL(issue.Fields.Project.Key), quoteJQL(issue.Fields.IssueType.Name), quoteJQL(status))
+	page, err := client.searchPage(ctx, jql, []string{"status"}, nil, 0)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to explore status '%s': %w", status, err)
+	}
+	if len(page.Issues) == 0 {
+		return nil, false, nil
+	}
+
+	transitions, err := client.GetTransitions(ctx, page.Issues[0].Key)
+	if err != nil {
+		return nil, false, err
+	}
+	return transitions, true, nil
+}
+
+// checkHopFields makes sure every `Name=value` assignment belongs on the screen of at least one hop
+func checkHopFields(path []hop, assignments map[string]string) error {
+	for name := range assignments {
+		found := false
+		for _, h := range path {
+			if _, ok := h.Transition.Field(name); ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("field '%s' is not on the screen of any transition along the way", name)
+		}
+	}
+	return nil
+}
+
+// moveIssue executes the hops of path in turn, setting the assigned fields on the screens
+// that have them. report is called after each successful hop. The error of a failed hop
+// names the step so the issue can be picked up from the status it reached.
+func (client *JiraClient) moveIssue(ctx context.Context, issueIDOrKey string, path []hop, assignments map[string]string, report func(step int, h hop)) error {
+	for i, h := range path {
+		if err := client.doHop(ctx, issueIDOrKey, h, assignments); err != nil {
+			return fmt.Errorf("step %d/%d %s failed: %w", i+1, len(path), h, err)
+		}
+		if report != nil {
+			report(i+1, h)
+		}
+	}
+	return nil
+}
+
+// doHop executes a single hop, using the transition as the issue offers it right now
+// since the path was explored partly on other issues
+func (client *JiraClient) doHop(ctx context.Context, issueIDOrKey string, h hop, assignments map[string]string) error {
+	transitions, err := client.GetTransitions(ctx, issueIDOrKey)
+	if err != nil {
+		return err
+	}
+
+	var transition *Transition
+	for i := range transitions {
+		t := &transitions[i]
+		if t.ID == h.Transition.ID || (t.To != nil && strings.EqualFold(t.To.Name, h.Transition.To.Name)) {
+			transition = t
+			break
+		}
+	}
+	if transition == nil {
+		return fmt.Errorf("transition '%s' is not available on %s", h.Transition.Name, issueIDOrKey)
+	}
+
+	fields := make(map[string]interface{})
+	for name, value := range assignments {
+		meta, ok := transition.Field(name)
+		if !ok {
+			continue
+		}
+		v, err := metaFieldValue(*meta, value)
+		if err != nil {
+			return err
+		}
+		fields[meta.FieldID] = v
+	}
+
+	missing, err := client.unsetRequiredFields(ctx, issueIDOrKey, transition, fields)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		descriptions := make([]string, 0, len(missing))
+		for _, meta := range missing {
+			descriptions = append(descriptions, describeFieldMeta(meta))
+		}
+		return fmt.Errorf("required fields are not set: %s", strings.Join(descriptions, "; "))
+	}
+
+	return client.DoTransition(ctx, issueIDOrKey, transition.ID, fields, "")
+}
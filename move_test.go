@@ -0,0 +1,202 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// testWorkflow maps each status onto its transitions as "Name>Target"
+type testWorkflow map[string][]string
+
+// transitionsOf serves the workflow to shortestPath and counts the statuses explored.
+// Statuses missing from the workflow cannot be explored.
+func (wf testWorkflow) transitionsOf(explored *[]string) func(status string) ([]Transition, bool, error) {
+	return func(status string) ([]Transition, bool, error) {
+		*explored = append(*explored, status)
+		specs, ok := wf[status]
+		if !ok {
+			return nil, false, nil
+		}
+		transitions := make([]Transition, 0, len(specs))
+		for i, spec := range specs {
+			name, to, _ := strings.Cut(spec, ">")
+			transitions = append(transitions, Transition{ID: fmt.Sprintf("%s-%d", status, i), Name: name, To: &Status{Name: to}})
+		}
+		return transitions, true, nil
+	}
+}
+
+// pathString renders a path as "Open>In Progress>Done"
+func pathString(start string, path []hop) string {
+	parts := []string{start}
+	for _, h := range path {
+		parts = append(parts, h.Transition.To.Name)
+	}
+	return strings.Join(parts, ">")
+}
+
+func TestShortestPath(t *testing.T) {
+	workflow := testWorkflow{
+		"Open":        {"Start>In Progress", "Triage>Backlog"},
+		"Backlog":     {"Plan>Selected"},
+		"Selected":    {"Start>In Progress"},
+		"In Progress": {"Submit>In Review", "Stop>Open"},
+		"In Review":   {"Approve>Done", "Reject>In Progress", "Fast track>QA"},
+		"QA":          {"Pass>Done"},
+		"Done":        {"Reopen>Open"},
+	}
+
+	tests := []struct {
+		start, target string
+		want          string
+	}{
+		{"Open", "In Progress", "Open>In Progress"},
+		{"Open", "Done", "Open>In Progress>In Review>Done"},
+		{"Open", "done", "Open>In Progress>In Review>Done"},
+		{"Backlog", "In Review", "Backlog>Selected>In Progress>In Review"},
+		{"Done", "Selected", "Done>Open>Backlog>Selected"},
+		// of two equally short paths the one through the first listed transition wins
+		{"In Review", "Open", "In Review>Done>Open"},
+	}
+
+	for _, tt := range tests {
+		var explored []string
+		path, unexplored, err := shortestPath(tt.start, tt.target, nil, workflow.transitionsOf(&explored))
+		if err != nil {
+			t.Errorf("%s to %s failed: %v", tt.start, tt.target, err)
+			continue
+		}
+		if got := pathString(tt.start, path); got != tt.want {
+			t.Errorf("%s to %s = %s, want %s", tt.start, tt.target, got, tt.want)
+		}
+		if unexplored != nil {
+			t.Errorf("%s to %s found a path but reported unexplored %q", tt.start, tt.target, unexplored)
+		}
+		for i, h := range path {
+			if i > 0 && h.From != path[i-1].Transition.To.Name {
+				t.Errorf("%s to %s: hop %d starts at %s, the previous one ends at %s", tt.start, tt.target, i, h.From, path[i-1].Transition.To.Name)
+			}
+		}
+	}
+}
+
+func TestShortestPathUsesGlobalTransitionsForUnexploredStatuses(t *testing.T) {
+	// nothing sits in Blocked, so its transitions are unknown
+	workflow := testWorkflow{
+		"Open": {"Block>Blocked"},
+	}
+	global := []Transition{{ID: "99", Name: "Close", To: &Status{Name: "Closed"}, IsGlobal: true}}
+
+	var explored []string
+	path, _, err := shortestPath("Open", "Archived", global, workflow.transitionsOf(&explored))
+	if err != nil || path != nil {
+		t.Fatalf("got path %v, err %v, want no path", path, err)
+	}
+
+	path, _, err = shortestPath("Open", "Closed", global, workflow.transitionsOf(&explored))
+	if err != nil {
+		t.Fatalf("shortestPath failed: %v", err)
+	}
+	if got := pathString("Open", path); got != "Open>Blocked>Closed" {
+		t.Errorf("path = %s, want Open>Blocked>Closed through the global transition", got)
+	}
+	if path[1].From != "Blocked" || path[1].Transition.ID != "99" {
+		t.Errorf("second hop = %+v, want the global transition out of Blocked", path[1])
+	}
+}
+
+func TestShortestPathNoPath(t *testing.T) {
+	workflow := testWorkflow{
+		"Open":        {"Start>In Progress"},
+		"In Progress": {"Submit>In Review", "Stop>Open"},
+	}
+
+	var explored []string
+	path, unexplored, err := shortestPath("Open", "Done", nil, workflow.transitionsOf(&explored))
+	if err != nil {
+		t.Fatalf("shortestPath failed: %v", err)
+	}
+	if path != nil {
+		t.Errorf("got path %s, want none", pathString("Open", path))
+	}
+	want := []string{"'In Review' (no issue in it to explore from)"}
+	if strings.Join(unexplored, "|") != strings.Join(want, "|") {
+		t.Errorf("unexplored = %q, want %q", unexplored, want)
+	}
+}
+
+func TestShortestPathLimits(t *testing.T) {
+	// a chain S0 > S1 > ... longer than maxMoveHops
+	chain := testWorkflow{}
+	for i := 0; i <= maxMoveHops+2; i++ {
+		chain[fmt.Sprintf("S%d", i)] = []string{fmt.Sprintf("Next>S%d", i+1)}
+	}
+
+	var explored []string
+	path, _, err := shortestPath("S0", fmt.Sprintf("S%d", maxMoveHops), nil, chain.transitionsOf(&explored))
+	if err != nil || len(path) != maxMoveHops {
+		t.Errorf("got %d hops, err %v, want a path of exactly %d hops", len(path), err, maxMoveHops)
+	}
+
+	explored = nil
+	path, unexplored, err := shortestPath("S0", fmt.Sprintf("S%d", maxMoveHops+1), nil, chain.transitionsOf(&explored))
+	if err != nil || path != nil {
+		t.Fatalf("got path %v, err %v, want no path beyond %d hops", path, err, maxMoveHops)
+	}
+	if len(unexplored) != 1 || !strings.Contains(unexplored[0], fmt.Sprintf("'S%d' (more than %d transitions away)", maxMoveHops, maxMoveHops)) {
+		t.Errorf("unexplored = %q", unexplored)
+	}
+	if len(explored) != maxMoveHops {
+		t.Errorf("explored %d statuses, want %d", len(explored), maxMoveHops)
+	}
+
+	// a fan out wider than maxMoveExplored
+	fan := testWorkflow{"Open": nil}
+	for i := 0; i < maxMoveExplored+5; i++ {
+		status := fmt.Sprintf("F%d", i)
+		fan["Open"] = append(fan["Open"], "Go>"+status)
+		fan[status] = []string{"Back>Open"}
+	}
+	explored = nil
+	path, unexplored, err = shortestPath("Open", "Done", nil, fan.transitionsOf(&explored))
+	if err != nil || path != nil {
+		t.Fatalf("got path %v, err %v, want no path", path, err)
+	}
+	if len(explored) != maxMoveExplored {
+		t.Errorf("explored %d statuses, want the limit of %d", len(explored), maxMoveExplored)
+	}
+	if len(unexplored) != 6 || !strings.Contains(unexplored[0], "exploration limit") {
+		t.Errorf("unexplored = %q, want the 6 statuses beyond the limit", unexplored)
+	}
+}
+
+func TestShortestPathError(t *testing.T) {
+	failure := errors.New("search failed")
+	_, _, err := shortestPath("Open", "Done", nil, func(status string) ([]Transition, bool, error) {
+		if status == "Open" {
+			return []Transition{{ID: "1", Name: "Start", To: &Status{Name: "In Progress"}}}, true, nil
+		}
+		return nil, false, failure
+	})
+	if !errors.Is(err, failure) {
+		t.Errorf("got error %v, want %v", err, failure)
+	}
+}
+
+func TestCheckHopFields(t *testing.T) {
+	path := []hop{
+		{From: "Open", Transition: Transition{Name: "Start", To: &Status{Name: "In Progress"}}},
+		{From: "In Review", Transition: Transition{Name: "Approve", To: &Status{Name: "Done"}, Fields: map[string]FieldMeta{
+			"resolution": {FieldID: "resolution", Name: "Resolution"},
+		}}},
+	}
+
+	if err := checkHopFields(path, map[string]string{"Resolution": "Fixed"}); err != nil {
+		t.Errorf("Resolution is on the Approve screen, got %v", err)
+	}
+	if err := checkHopFields(path, map[string]string{"Fix Version": "1.0"}); err == nil {
+		t.Error("Fix Version is on no screen, want an error")
+	}
+}
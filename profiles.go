@@ -0,0 +1,307 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profile is a named set of connection settings in the config file.
+// Empty values fall through to the environment and the .env file.
+type Profile struct {
+	BaseURL  string `yaml:"base_url"`
+	Username string `yaml:"username"`
+	PAT      string `yaml:"pat,omitempty"`
+	APIToken string `yaml:"api_token,omitempty"`
+	UsePAT   bool   `yaml:"use_pat,omitempty"`
+	Auth     string `yaml:"auth,omitempty"` // basic, pat, cookie or oauth1
+
+	// PATCommand prints the token, e.g. "pass show jira/pat", so it need not be stored here
+	PATCommand string `yaml:"pat_command,omitempty"`
+
+	OAuthConsumerKey string `yaml:"oauth_consumer_key,omitempty"`
+	OAuthPrivateKey  string `yaml:"oauth_private_key,omitempty"`
+	OAuthToken       string `yaml:"oauth_token,omitempty"`
+
+	StoryPointsField        string `yaml:"story_points_field,omitempty"`
+	AcceptanceCriteriaField string `yaml:"acceptance_criteria_field,omitempty"`
+
+	// AutoWatchers is a comma separated list of users to add as watchers of new issues of AutoWatchTypes
+	AutoWatchers   string `yaml:"auto_watchers,omitempty"`
+	AutoWatchTypes string `yaml:"auto_watch_types,omitempty"`
+
+	Timeout    string `yaml:"timeout,omitempty"`
+	MaxRetries string `yaml:"max_retries,omitempty"`
+	RetryDelay string `yaml:"retry_delay,omitempty"`
+	RateLimit  string `yaml:"rate_limit,omitempty"`
+}
+
+// lookup returns the profile value for the equivalent environment variable
+func (p Profile) lookup(key string) string {
+	switch key {
+	case "JIRA_BASE_URL":
+		return p.BaseURL
+	case "JIRA_USERNAME":
+		return p.Username
+	case "JIRA_PAT":
+		return p.PAT
+	case "JIRA_API_TOKEN":
+		return p.APIToken
+	case "JIRA_PAT_COMMAND":
+		return p.PATCommand
+	case "JIRA_AUTH":
+		return p.Auth
+	case "JIRA_OAUTH_CONSUMER_KEY":
+		return p.OAuthConsumerKey
+	case "JIRA_OAUTH_PRIVATE_KEY":
+		return p.OAuthPrivateKey
+	case "JIRA_OAUTH_TOKEN":
+		return p.OAuthToken
+	case "JIRA_USE_PAT":
+		if p.UsePAT {
+			return "true"
+		}
+	case "JIRA_FIELD_STORY_POINTS":
+		return p.StoryPointsField
+	case "JIRA_FIELD_ACCEPTANCE_CRITERIA":
+		return p.AcceptanceCriteriaField
+	case "JIRA_AUTO_WATCHERS":
+		return p.AutoWatchers
+	case "JIRA_AUTO_WATCH_TYPES":
+		return p.AutoWatchTypes
+	case "JIRA_TIMEOUT":
+		return p.Timeout
+	case "JIRA_MAX_RETRIES":
+		return p.MaxRetries
+	case "JIRA_RETRY_DELAY":
+		return p.RetryDelay
+	case "JIRA_RATE_LIMIT":
+		return p.RateLimit
+	}
+	return ""
+}
+
+// ProfileFile is the content of the jeera config file
+type ProfileFile struct {
+	Current  string             `yaml:"current,omitempty"` // profile used when none is named
+	Profiles map[string]Profile `yaml:"profiles"`
+
+	path string
+}
+
+// Names returns the profile names in alphabetical order
+func (f *ProfileFile) Names() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// profilePath returns the location of the config file, overridable with JEERA_CONFIG
+func profilePath() (string, error) {
+	if path := envSetting("JEERA_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %v", err)
+	}
+	return filepath.Join(dir, "jeera", "config.yaml"), nil
+}
+
+// LoadProfiles reads the config file. A missing file yields no profiles.
+func LoadProfiles() (*ProfileFile, error) {
+	path, err := profilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	file := &ProfileFile{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return file, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %v", err)
+	}
+
+	if err := yaml.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	if info, err := os.Stat(path); err == nil && info.Mode().Perm()&0o077 != 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %s may contain tokens and is readable by other users, run chmod 600 on it\n", path)
+	}
+
+	return file, nil
+}
+
+// setCurrentProfile records the default profile in the config file.
+// The file is edited as a YAML document so comments and layout survive.
+func setCurrentProfile(file *ProfileFile, name string) error {
+	data, err := os.ReadFile(file.path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %v", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse %s: %v", file.path, err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to parse %s: expected a mapping at the top level", file.path)
+	}
+
+	root := doc.Content[0]
+	found := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "current" {
+			root.Content[i+1].SetString(name)
+			found = true
+			break
+		}
+	}
+	if !found {
+		key := &yaml.Node{Kind: yaml.ScalarNode}
+		key.SetString("current")
+		value := &yaml.Node{Kind: yaml.ScalarNode}
+		value.SetString(name)
+		// keep a comment heading the file above the new key
+		if len(root.Content) > 0 {
+			key.HeadComment, root.Content[0].HeadComment = root.Content[0].HeadComment, ""
+		}
+		root.Content = append([]*yaml.Node{key, value}, root.Content...)
+	}
+
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode config file: %v", err)
+	}
+	enc.Close()
+	if err := os.WriteFile(file.path, out.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %v", err)
+	}
+
+	file.Current = name
+	return nil
+}
+
+// printProfiles lists the profiles, marking the one in use
+func printProfiles(w io.Writer, file *ProfileFile, active string) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "\tNAME\tBASE URL\tUSERNAME")
+	for _, name := range file.Names() {
+		marker := ""
+		if name == active {
+			marker = "*"
+		}
+		profile := file.Profiles[name]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", marker, name, profile.BaseURL, profile.Username)
+	}
+	tw.Flush()
+}
+
+// configView is the effective configuration as `config show -o` encodes it. Like
+// printConfig it only says where a token comes from, never what it is.
+type configView struct {
+	Profile                 string   `json:"profile"`
+	BaseURL                 string   `json:"baseUrl"`
+	Username                string   `json:"username"`
+	Auth                    string   `json:"auth"`
+	TokenSource             string   `json:"tokenSource,omitempty"`
+	OAuthConsumerKey        string   `json:"oauthConsumerKey,omitempty"`
+	OAuthPrivateKey         string   `json:"oauthPrivateKey,omitempty"`
+	OAuthTokenSet           bool     `json:"oauthTokenSet,omitempty"`
+	StoryPointsField        string   `json:"storyPointsField"`
+	AcceptanceCriteriaField string   `json:"acceptanceCriteriaField"`
+	AutoWatchers            []string `json:"autoWatchers,omitempty"`
+	AutoWatchTypes          []string `json:"autoWatchTypes,omitempty"`
+	Timeout                 string   `json:"timeout"`
+	MaxRetries              int      `json:"maxRetries"`
+	RetryDelay              string   `json:"retryDelay"`
+	RateLimit               float64  `json:"rateLimit"`
+}
+
+// newConfigView describes config without any of its secrets
+func newConfigView(config *Config) configView {
+	view := configView{
+		Profile:                 config.Profile,
+		BaseURL:                 config.BaseURL,
+		Username:                config.Username,
+		Auth:                    config.AuthMode,
+		StoryPointsField:        config.StoryPointsField,
+		AcceptanceCriteriaField: config.AcceptanceCriteriaField,
+		Timeout:                 config.RequestTimeout.String(),
+		MaxRetries:              config.MaxRetries,
+		RetryDelay:              config.RetryBaseDelay.String(),
+		RateLimit:               config.RateLimit,
+	}
+	if config.AuthMode == authOAuth1 {
+		view.OAuthConsumerKey = config.OAuthConsumerKey
+		view.OAuthPrivateKey = config.OAuthPrivateKey
+		view.OAuthTokenSet = config.OAuthToken != ""
+	} else {
+		view.TokenSource = config.TokenSource
+	}
+	if len(config.AutoWatchers) > 0 {
+		view.AutoWatchers = config.AutoWatchers
+		view.AutoWatchTypes = config.AutoWatchTypes
+	}
+	return view
+}
+
+// printConfig prints the effective configuration. Tokens are never printed,
+// only whether one is set and how it will be used.
+func printConfig(w io.Writer, config *Config) {
+	profile := config.Profile
+	if profile == "" {
+		profile = "(none)"
+	}
+
+	auth := config.AuthMode
+	if auth == "" {
+		auth = "probed on first request (set JIRA_AUTH to skip)"
+	}
+
+	token := "(not set)"
+	if config.TokenSource != "" {
+		token = "set from " + config.TokenSource
+	}
+
+	fmt.Fprintf(w, "Profile: %s\n", profile)
+	fmt.Fprintf(w, "Base URL: %s\n", config.BaseURL)
+	fmt.Fprintf(w, "Username: %s\n", config.Username)
+	fmt.Fprintf(w, "Authentication: %s\n", auth)
+	if config.AuthMode == authOAuth1 {
+		fmt.Fprintf(w, "OAuth consumer key: %s\n", config.OAuthConsumerKey)
+		fmt.Fprintf(w, "OAuth private key: %s\n", config.OAuthPrivateKey)
+		if config.OAuthToken != "" {
+			fmt.Fprintf(w, "OAuth access token: set\n")
+		} else {
+			fmt.Fprintf(w, "OAuth access token: (not set)\n")
+		}
+	} else {
+		fmt.Fprintf(w, "Token: %s\n", token)
+	}
+	fmt.Fprintf(w, "Story points field: %s\n", config.StoryPointsField)
+	fmt.Fprintf(w, "Acceptance criteria field: %s\n", config.AcceptanceCriteriaField)
+	if len(config.AutoWatchers) > 0 {
+		fmt.Fprintf(w, "Auto watchers: %s on %s\n", strings.Join(config.AutoWatchers, ", "), strings.Join(config.AutoWatchTypes, ", "))
+	}
+	fmt.Fprintf(w, "Timeout: %v\n", config.RequestTimeout)
+	fmt.Fprintf(w, "Max retries: %d\n", config.MaxRetries)
+	fmt.Fprintf(w, "Retry delay: %v\n", config.RetryBaseDelay)
+	fmt.Fprintf(w, "Rate limit: %g requests/s\n", config.RateLimit)
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by --output
+const (
+	outputTable    = "table"
+	outputJSON     = "json"
+	outputYAML     = "yaml"
+	outputCSV      = "csv"
+	outputTemplate = "template"
+)
+
+// outputFormat is the value of the --output flag of the read commands
+type outputFormat struct {
+	kind     string
+	text     string // template source when kind is template
+	template *template.Template
+}
+
+func (f *outputFormat) String() string {
+	if f.kind == outputTemplate {
+		return outputTemplate + "=" + f.text
+	}
+	return f.kind
+}
+
+func (f *outputFormat) Set(value string) error {
+	if text, ok := strings.CutPrefix(value, outputTemplate+"="); ok {
+		tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+		if err != nil {
+			return fmt.Errorf("invalid template: %v", err)
+		}
+		f.kind, f.text, f.template = outputTemplate, text, tmpl
+		return nil
+	}
+
+	switch value {
+	case outputTable, outputJSON, outputYAML, outputCSV:
+		f.kind = value
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q, expected table, json, yaml, csv or template=<go-template>", value)
+}
+
+// templateFuncs are the helpers available in --output template=...
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// addOutputFlag registers --output and its shorthand -o on a subcommand flag set
+func addOutputFlag(fs *flag.FlagSet) *outputFormat {
+	format := &outputFormat{kind: outputTable}
+	fs.Var(format, "output", "output format: table, json, yaml, csv or template=<go-template>")
+	fs.Var(format, "o", "shorthand for --output")
+	return format
+}
+
+// records is what a read command prints, ready for every output format
+type records struct {
+	items   []interface{} // values encoded as JSON or YAML and passed to templates
+	single  bool          // encode the only item as an object instead of a list
+	columns []string      // CSV header
+	rows    [][]string    // CSV rows, one per item
+	table   func()        // the human readable layout
+}
+
+// print writes the records to stdout in the chosen format.
+// Templates run once per item and each result ends with a newline.
+func (f *outputFormat) print(r records) error {
+	switch f.kind {
+	case outputJSON, outputYAML:
+		var data interface{} = r.items
+		if r.single && len(r.items) == 1 {
+			data = r.items[0]
+		}
+		encoded, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode output: %v", err)
+		}
+		if f.kind == outputJSON {
+			fmt.Println(string(encoded))
+			return nil
+		}
+
+		// YAML goes through JSON so both formats share the same keys
+		var generic interface{}
+		if err := json.Unmarshal(encoded, &generic); err != nil {
+			return fmt.Errorf("failed to encode output: %v", err)
+		}
+		enc := yaml.NewEncoder(os.Stdout)
+		enc.SetIndent(2)
+		if err := enc.Encode(generic); err != nil {
+			return fmt.Errorf("failed to encode output: %v", err)
+		}
+		return enc.Close()
+
+	case outputCSV:
+		w := csv.NewWriter(os.Stdout)
+		w.Write(r.columns)
+		w.WriteAll(r.rows)
+		return w.Error()
+
+	case outputTemplate:
+		for _, item := range r.items {
+			var b strings.Builder
+			if err := f.template.Execute(&b, item); err != nil {
+				return fmt.Errorf("failed to render template: %v", err)
+			}
+			out := b.String()
+			if !strings.HasSuffix(out, "\n") {
+				out += "\n"
+			}
+			fmt.Print(out)
+		}
+		return nil
+	}
+
+	r.table()
+	return nil
+}
+
+// issueRecords renders issues with table as their human readable layout
+func issueRecords(issues []Issue, table func()) records {
+	r := records{
+		items:   make([]interface{}, 0, len(issues)),
+		columns: []string{"key", "summary", "status", "type", "priority", "assignee", "story points"},
+		table:   table,
+	}
+	for i := range issues {
+		issue := &issues[i]
+		r.items = append(r.items, issue)
+		r.rows = append(r.rows, []string{
+			issue.Key,
+			issue.Fields.Summary,
+			issue.Fields.StatusName(),
+			issue.Fields.IssueTypeName(),
+			issue.Fields.PriorityName(),
+			issue.Fields.AssigneeName(),
+			storyPointsText(issue.Fields.StoryPoints),
+		})
+	}
+	return r
+}
+
+// storyPointsText formats story points for a CSV cell, empty when they are not set
+func storyPointsText(points *float32) string {
+	if points == nil {
+		return ""
+	}
+	return fmt.Sprintf("%g", *points)
+}
+
+// commentRecords renders comments with table as their human readable layout
+func commentRecords(comments []Comment, table func()) records {
+	r := records{
+		items:   make([]interface{}, 0, len(comments)),
+		columns: []string{"id", "author", "created", "updated", "body"},
+		table:   table,
+	}
+	for i := range comments {
+		c := &comments[i]
+		r.items = append(r.items, c)
+		r.rows = append(r.rows, []string{c.ID, c.AuthorName(), c.Created.Format(jiraTimeLayout), c.Updated.Format(jiraTimeLayout), c.Body})
+	}
+	return r
+}
+
+// transitionRecords renders transitions with table as their human readable layout
+func transitionRecords(transitions []Transition, table func()) records {
+	r := records{
+		items:   make([]interface{}, 0, len(transitions)),
+		columns: []string{"id", "name", "to"},
+		table:   table,
+	}
+	for i := range transitions {
+		t := &transitions[i]
+		r.items = append(r.items, t)
+		to := ""
+		if t.To != nil {
+			to = t.To.Name
+		}
+		r.rows = append(r.rows, []string{t.ID, t.Name, to})
+	}
+	return r
+}
+
+// worklogRecords renders worklogs with table as their human readable layout
+func worklogRecords(worklogs []Worklog, table func()) records {
+	r := records{
+		items:   make([]interface{}, 0, len(worklogs)),
+		columns: []string{"id", "author", "started", "seconds", "time spent", "comment"},
+		table:   table,
+	}
+	for i := range worklogs {
+		w := &worklogs[i]
+		r.items = append(r.items, w)
+		r.rows = append(r.rows, []string{
+			w.ID,
+			w.AuthorName(),
+			w.Started.Format(jiraTimeLayout),
+			fmt.Sprint(w.TimeSpentSeconds),
+			formatDuration(w.TimeSpentSeconds),
+			w.Comment,
+		})
+	}
+	return r
+}
+
+// attachmentRecords renders attachments with table as their human readable layout
+func attachmentRecords(attachments []Attachment, table func()) records {
+	r := records{
+		items:   make([]interface{}, 0, len(attachments)),
+		columns: []string{"id", "filename", "size", "author", "created", "mime type"},
+		table:   table,
+	}
+	for i := range attachments {
+		a := &attachments[i]
+		r.items = append(r.items, a)
+		r.rows = append(r.rows, []string{
+			a.ID,
+			a.Filename,
+			fmt.Sprint(a.Size),
+			a.AuthorName(),
+			a.Created.Format(jiraTimeLayout),
+			a.MimeType,
+		})
+	}
+	return r
+}
+
+// userRecords renders users with table as their human readable layout
+func userRecords(users []User, table func()) records {
+	r := records{
+		items:   make([]interface{}, 0, len(users)),
+		columns: []string{"name", "display name", "email", "active"},
+		table:   table,
+	}
+	for i := range users {
+		u := &users[i]
+		r.items = append(r.items, u)
+		r.rows = append(r.rows, []string{u.Name, u.DisplayName, u.EmailAddress, fmt.Sprint(u.Active)})
+	}
+	return r
+}
+
+// linkRecords renders the links of an issue with table as their human readable layout
+func linkRecords(links []IssueLink, table func()) records {
+	r := records{
+		items:   make([]interface{}, 0, len(links)),
+		columns: []string{"id", "relation", "key", "status", "summary"},
+		table:   table,
+	}
+	for i := range links {
+		link := &links[i]
+		r.items = append(r.items, link)
+		key, status, summary := "", "", ""
+		if other := link.Other(); other != nil {
+			key, status, summary = other.Key, other.Fields.StatusName(), other.Fields.Summary
+		}
+		r.rows = append(r.rows, []string{link.ID, link.Relation(), key, status, summary})
+	}
+	return r
+}
+
+// linkTypeRecords renders issue link types with table as their human readable layout
+func linkTypeRecords(types []IssueLinkType, table func()) records {
+	r := records{
+		items:   make([]interface{}, 0, len(types)),
+		columns: []string{"id", "name", "outward", "inward"},
+		table:   table,
+	}
+	for i := range types {
+		t := &types[i]
+		r.items = append(r.items, t)
+		r.rows = append(r.rows, []string{t.ID, t.Name, t.Outward, t.Inward})
+	}
+	return r
+}
+
+// fieldRecords renders fields with table as their human readable layout
+func fieldRecords(fields []Field, table func()) records {
+	r := records{
+		items:   make([]interface{}, 0, len(fields)),
+		columns: []string{"name", "id", "type", "custom"},
+		table:   table,
+	}
+	for i := range fields {
+		f := &fields[i]
+		r.items = append(r.items, f)
+		r.rows = append(r.rows, []string{f.Name, f.ID, schemaString(f.Schema), fmt.Sprint(f.Custom)})
+	}
+	return r
+}
+
+// fieldMetaRecords renders editable fields with table as their human readable layout
+func fieldMetaRecords(meta []FieldMeta, table func()) records {
+	r := records{
+		items:   make([]interface{}, 0, len(meta)),
+		columns: []string{"name", "id", "type", "required", "operations", "allowed values"},
+		table:   table,
+	}
+	for i := range meta {
+		m := &meta[i]
+		r.items = append(r.items, m)
+		allowed := make([]string, 0, len(m.AllowedValues))
+		for _, v := range m.AllowedValues {
+			allowed = append(allowed, formatFieldValue(v))
+		}
+		r.rows = append(r.rows, []string{m.Name, m.FieldID, schemaString(m.Schema), fmt.Sprint(m.Required),
+			strings.Join(m.Operations, ","), strings.Join(allowed, ", ")})
+	}
+	return r
+}
+
+// configRecords renders the effective configuration with table as its human readable layout
+func configRecords(config *Config, table func()) records {
+	view := newConfigView(config)
+	return records{
+		items:  []interface{}{view},
+		single: true,
+		columns: []string{"profile", "base url", "username", "auth", "token source", "story points field",
+			"acceptance criteria field", "auto watchers", "auto watch types", "timeout", "max retries", "retry delay", "rate limit"},
+		rows: [][]string{{view.Profile, view.BaseURL, view.Username, view.Auth, view.TokenSource, view.StoryPointsField,
+			view.AcceptanceCriteriaField, strings.Join(view.AutoWatchers, ","), strings.Join(view.AutoWatchTypes, ","),
+			view.Timeout, fmt.Sprint(view.MaxRetries), view.RetryDelay, fmt.Sprint(view.RateLimit)}},
+		table: table,
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Defaults for the retry and rate limiting behaviour of makeRequest
+const (
+	defaultMaxRetries     = 3
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	maxRetryDelay         = 30 * time.Second
+	defaultRateLimit      = 10 // requests per second
+	defaultRequestTimeout = 30 * time.Second
+)
+
+// isIdempotent reports whether a request with this method can be repeated safely
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// deletedBefore reports whether resp is the 404 of a DELETE repeated after an attempt
+// JIRA may have processed: the resource is gone either way, so the delete succeeded
+func deletedBefore(method string, resp *http.Response) bool {
+	return method == http.MethodDelete && resp.StatusCode == http.StatusNotFound
+}
+
+// noContent is the response standing in for a delete confirmed by deletedBefore
+func noContent(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:     "204 No Content",
+		StatusCode: http.StatusNoContent,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Body:       http.NoBody,
+		Request:    req,
+	}
+}
+
+// retryDelay decides whether a request should be attempted again and how long to wait first.
+// Idempotent requests are retried on network errors, 5xx and 429. Other requests are only
+// retried on 429, since JIRA rejects throttled requests before processing them.
+func (client *JiraClient) retryDelay(method string, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if attempt >= client.config.MaxRetries {
+		return 0, false
+	}
+
+	switch {
+	case err != nil:
+		if !isIdempotent(method) {
+			return 0, false
+		}
+	case resp.StatusCode == http.StatusTooManyRequests:
+	case resp.StatusCode >= 500 && isIdempotent(method):
+	default:
+		return 0, false
+	}
+
+	if resp != nil {
+		if delay, ok := retryAfter(resp.Header); ok {
+			return delay, true
+		}
+	}
+
+	return backoff(client.config.RetryBaseDelay, attempt), true
+}
+
+// backoff returns an exponential delay for the given attempt with jitter,
+// picked between half and the full exponential value
+func backoff(base time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	delay := base << attempt
+	if delay <= 0 || delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an HTTP date
+func retryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return capDelay(time.Duration(seconds) * time.Second), true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return capDelay(time.Until(at)), true
+	}
+
+	return 0, false
+}
+
+// capDelay keeps a server-provided delay between zero and maxRetryDelay
+func capDelay(delay time.Duration) time.Duration {
+	if delay < 0 {
+		return 0
+	}
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
+
+// rateLimiter spaces requests out evenly and honours pauses announced by the server
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // minimum time between two requests, 0 disables limiting
+	next     time.Time     // earliest time the next request may start
+}
+
+// newRateLimiter creates a limiter allowing perSecond requests per second; 0 means unlimited
+func newRateLimiter(perSecond float64) *rateLimiter {
+	limiter := &rateLimiter{}
+	if perSecond > 0 {
+		limiter.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+	return limiter
+}
+
+// wait blocks until the caller may send its request or ctx is cancelled
+func (l *rateLimiter) wait(ctx context.Context) error {
+	return sleepContext(ctx, l.reserve())
+}
+
+// sleepContext pauses for d, returning early with the context error if ctx is cancelled
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve claims the next request slot and returns how long to wait for it
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	return delay
+}
+
+// observe adapts the limiter to the X-RateLimit-* headers of a response.
+// JIRA Data Center announces a token bucket through FillRate and Interval-Seconds,
+// JIRA Cloud announces when an exhausted budget resets.
+func (l *rateLimiter) observe(header http.Header) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	fillRate, errRate := strconv.ParseFloat(header.Get("X-RateLimit-FillRate"), 64)
+	intervalSeconds, errInterval := strconv.ParseFloat(header.Get("X-RateLimit-Interval-Seconds"), 64)
+	if errRate == nil && errInterval == nil && fillRate > 0 && intervalSeconds > 0 {
+		// never go faster than the server refills, but keep a stricter local limit
+		serverInterval := time.Duration(intervalSeconds / fillRate * float64(time.Second))
+		if serverInterval > l.interval {
+			l.interval = serverInterval
+		}
+	}
+
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil || remaining > 0 {
+		return
+	}
+
+	// the budget is exhausted: hold every request until it resets
+	resume := time.Now().Add(l.interval)
+	if reset, err := time.Parse(time.RFC3339, header.Get("X-RateLimit-Reset")); err == nil {
+		resume = reset
+	} else if delay, ok := retryAfter(header); ok {
+		resume = time.Now().Add(delay)
+	}
+	if limit := time.Now().Add(maxRetryDelay); resume.After(limit) {
+		resume = limit
+	}
+	if resume.After(l.next) {
+		l.next = resume
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIsIdempotent(t *testing.T) {
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete} {
+		if !isIdempotent(method) {
+			t.Errorf("%s should be idempotent", method)
+		}
+	}
+	for _, method := range []string{http.MethodPost, http.MethodPatch} {
+		if isIdempotent(method) {
+			t.Errorf("%s should not be idempotent", method)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	client := &JiraClient{config: &Config{MaxRetries: 3, RetryBaseDelay: 100 * time.Millisecond}}
+	status := func(code int, header ...string) *http.Response {
+		resp := &http.Response{StatusCode: code, Header: http.Header{}}
+		for i := 0; i+1 < len(header); i += 2 {
+			resp.Header.Set(header[i], header[i+1])
+		}
+		return resp
+	}
+	networkError := errors.New("connection reset by peer")
+
+	tests := []struct {
+		name    string
+		method  string
+		resp    *http.Response
+		err     error
+		attempt int
+		retry   bool
+		min     time.Duration
+		max     time.Duration
+	}{
+		{name: "GET network error", method: "GET", err: networkError, retry: true, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{name: "POST network error", method: "POST", err: networkError, retry: false},
+		{name: "GET 503", method: "GET", resp: status(503), retry: true, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{name: "PUT 500 second attempt", method: "PUT", resp: status(500), attempt: 1, retry: true, min: 100 * time.Millisecond, max: 200 * time.Millisecond},
+		{name: "DELETE 502 third attempt", method: "DELETE", resp: status(502), attempt: 2, retry: true, min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+		{name: "POST 500", method: "POST", resp: status(500), retry: false},
+		{name: "POST 429", method: "POST", resp: status(429), retry: true, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{name: "429 with Retry-After", method: "GET", resp: status(429, "Retry-After", "7"), retry: true, min: 7 * time.Second, max: 7 * time.Second},
+		{name: "503 with Retry-After", method: "GET", resp: status(503, "Retry-After", "2"), retry: true, min: 2 * time.Second, max: 2 * time.Second},
+		{name: "GET 404", method: "GET", resp: status(404), retry: false},
+		{name: "GET 400", method: "GET", resp: status(400), retry: false},
+		{name: "GET 200", method: "GET", resp: status(200), retry: false},
+		{name: "retries used up", method: "GET", resp: status(503), attempt: 3, retry: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, retry := client.retryDelay(tt.method, tt.resp, tt.err, tt.attempt)
+			if retry != tt.retry {
+				t.Fatalf("retry = %v, want %v", retry, tt.retry)
+			}
+			if retry && (delay < tt.min || delay > tt.max) {
+				t.Errorf("delay = %v, want between %v and %v", delay, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	if got := backoff(0, 3); got != 0 {
+		t.Errorf("backoff without base delay = %v, want 0", got)
+	}
+	for attempt := 0; attempt < 5; attempt++ {
+		full := time.Second << attempt
+		for i := 0; i < 20; i++ {
+			if got := backoff(time.Second, attempt); got < full/2 || got > full {
+				t.Errorf("backoff(1s, %d) = %v, want between %v and %v", attempt, got, full/2, full)
+			}
+		}
+	}
+	// large attempts are capped instead of overflowing
+	for _, attempt := range []int{10, 40, 70} {
+		if got := backoff(time.Second, attempt); got < maxRetryDelay/2 || got > maxRetryDelay {
+			t.Errorf("backoff(1s, %d) = %v, want at most %v", attempt, got, maxRetryDelay)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{value: "", ok: false},
+		{value: "0", want: 0, ok: true},
+		{value: "5", want: 5 * time.Second, ok: true},
+		{value: "3600", want: maxRetryDelay, ok: true},
+		{value: "-1", ok: false},
+		{value: "soon", ok: false},
+		{value: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), want: 0, ok: true},
+		{value: time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), want: maxRetryDelay, ok: true},
+	}
+
+	for _, tt := range tests {
+		header := http.Header{}
+		if tt.value != "" {
+			header.Set("Retry-After", tt.value)
+		}
+		got, ok := retryAfter(header)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("retryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	// an HTTP date a few seconds ahead waits until then
+	header := http.Header{"Retry-After": {time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)}}
+	if got, ok := retryAfter(header); !ok || got < 8*time.Second || got > 10*time.Second {
+		t.Errorf("retryAfter(10s ahead) = %v, %v", got, ok)
+	}
+}
+
+// TestRetriedDeleteNotFound checks that a DELETE repeated after an attempt JIRA may have
+// processed treats the resource being gone as success
+func TestRetriedDeleteNotFound(t *testing.T) {
+	tests := []struct {
+		name  string
+		first func(w http.ResponseWriter)
+		want  int
+	}{
+		{
+			name: "after a dropped connection",
+			first: func(w http.ResponseWriter) {
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				conn.Close()
+			},
+			want: http.StatusNoContent,
+		},
+		{
+			name:  "after a 502",
+			first: func(w http.ResponseWriter) { w.WriteHeader(http.StatusBadGateway) },
+			want:  http.StatusNoContent,
+		},
+		{
+			// a throttled request was never processed, so the 404 is genuine
+			name:  "after a 429",
+			first: func(w http.ResponseWriter) { w.WriteHeader(http.StatusTooManyRequests) },
+			want:  http.StatusNotFound,
+		},
+		{
+			name:  "without a retry",
+			first: func(w http.ResponseWriter) { w.WriteHeader(http.StatusNotFound) },
+			want:  http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) == 1 {
+					tt.first(w)
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+			}))
+			defer server.Close()
+
+			client := NewJiraClient(&Config{
+				BaseURL:    server.URL,
+				AuthMode:   authPAT,
+				APIToken:   "token",
+				MaxRetries: 2,
+			})
+			resp, err := client.makeRequest(context.Background(), http.MethodDelete, "/rest/api/2/issueLink/1", nil)
+			if err != nil {
+				t.Fatalf("makeRequest failed: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// searchPageSize is the number of issues requested per page; JIRA may cap it lower
+const searchPageSize = 100
+
+// searchTableFields are the fields needed to print a search result table
+var searchTableFields = []string{"summary", "status", "assignee"}
+
+// searchResponse represents a single page of a JQL search result
+type searchResponse struct {
+	StartAt    int     `json:"startAt"`
+	MaxResults int     `json:"maxResults"`
+	Total      int     `json:"total"`
+	Issues     []Issue `json:"issues"`
+}
+
+// SearchIssues runs a JQL query and follows the pagination until every matching issue is fetched.
+// fields and expand are optional; nil leaves the JIRA defaults in place.
+func (client *JiraClient) SearchIssues(ctx context.Context, jql string, fields, expand []string) ([]Issue, error) {
+	var issues []Issue
+	startAt := 0
+
+	for {
+		page, err := client.searchPage(ctx, jql, fields, expand, startAt)
+		if err != nil {
+			if startAt > 0 {
+				return nil, fmt.Errorf("search stopped after fetching %d issues: %w", startAt, err)
+			}
+			return nil, err
+		}
+
+		issues = append(issues, page.Issues...)
+		startAt += len(page.Issues)
+
+		if *DEBUGflag {
+			fmt.Printf("SearchIssues fetched %d/%d issues\n", startAt, page.Total)
+		}
+
+		// an empty page guards against looping forever if total is inconsistent
+		if len(page.Issues) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+
+	return issues, nil
+}
+
+// searchPage fetches a single page of search results starting at startAt
+func (client *JiraClient) searchPage(ctx context.Context, jql string, fields, expand []string, startAt int) (*searchResponse, error) {
+	params := url.Values{}
+	params.Set("jql", jql)
+	params.Set("startAt", strconv.Itoa(startAt))
+	params.Set("maxResults", strconv.Itoa(searchPageSize))
+	if len(fields) > 0 {
+		params.Set("fields", strings.Join(fields, ","))
+	}
+	if len(expand) > 0 {
+		params.Set("expand", strings.Join(expand, ","))
+	}
+
+	endpoint := "/rest/api/2/search?" + params.Encode()
+
+	resp, err := client.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.apiError(resp, "search issues")
+	}
+
+	var page searchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+	for i := range page.Issues {
+		client.applyCustomFields(ctx, &page.Issues[i])
+	}
+
+	return &page, nil
+}
@@ -0,0 +1,368 @@
+package main
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"syscall"
+
+	"golang.org/x/term"
+)
+
+// Token kinds kept in the encrypted token store
+const (
+	tokenKindPAT      = "pat"
+	tokenKindAPIToken = "api_token"
+)
+
+// tokenStoreIterations is the PBKDF2-SHA256 work factor for the store passphrase
+const tokenStoreIterations = 600000
+
+// tokenFromCommand runs a secret command such as `pass show jira/pat` through the shell
+// and returns the first line it prints. The command gets the controlling terminal as
+// stdin, never piped input meant for jeera, so pinentry-style helpers can still ask
+// for a passphrase; stderr stays attached for their prompts.
+func tokenFromCommand(command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	if tty, err := os.Open("/dev/tty"); err == nil {
+		defer tty.Close()
+		cmd.Stdin = tty
+	}
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("token command %q failed: %v", command, err)
+	}
+
+	token, _, _ := strings.Cut(string(out), "\n")
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", fmt.Errorf("token command %q printed no token", command)
+	}
+	return token, nil
+}
+
+// netrcPath returns the netrc file to read, $NETRC or ~/.netrc
+func netrcPath() string {
+	if path := envSetting("NETRC"); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	name := ".netrc"
+	if runtime.GOOS == "windows" {
+		name = "_netrc"
+	}
+	return filepath.Join(home, name)
+}
+
+// netrcCredentials returns the login and password for the host of baseURL from
+// the netrc file, falling back to its default entry. A missing file finds nothing.
+func netrcCredentials(baseURL string) (login, password string, err error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Hostname() == "" {
+		return "", "", nil
+	}
+
+	path := netrcPath()
+	if path == "" {
+		return "", "", nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	// collect the tokens, skipping macro definitions which run until an empty line
+	var tokens []string
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 0 && fields[0] == "macdef" {
+			for scanner.Scan() && strings.TrimSpace(scanner.Text()) != "" {
+			}
+			continue
+		}
+		tokens = append(tokens, fields...)
+	}
+
+	type entry struct{ login, password string }
+	var found, fallback, current *entry
+
+	for i := 0; i < len(tokens); i++ {
+		var value string
+		if i+1 < len(tokens) {
+			value = tokens[i+1]
+		}
+
+		switch tokens[i] {
+		case "machine":
+			current = nil
+			if found == nil && strings.EqualFold(value, u.Hostname()) {
+				found = &entry{}
+				current = found
+			}
+			i++
+		case "default":
+			current = nil
+			if fallback == nil {
+				fallback = &entry{}
+				current = fallback
+			}
+		case "login":
+			if current != nil {
+				current.login = value
+			}
+			i++
+		case "password":
+			if current != nil {
+				current.password = value
+			}
+			i++
+		case "account":
+			i++
+		}
+	}
+
+	if found == nil {
+		found = fallback
+	}
+	if found == nil {
+		return "", "", nil
+	}
+	return found.login, found.password, nil
+}
+
+// tokenStore is the local encrypted token store. Every entry is encrypted on its own
+// with a key derived from the passphrase, so looking up which URLs have a token never
+// needs the passphrase.
+type tokenStore struct {
+	Entries map[string]tokenStoreEntry `json:"entries"`
+
+	path string
+}
+
+// tokenStoreEntry is one encrypted token, bound to the base URL it is stored for
+type tokenStoreEntry struct {
+	Kind       string `json:"kind"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// tokenStorePath returns the location of the token store, overridable with JEERA_TOKEN_STORE
+func tokenStorePath() (string, error) {
+	if path := envSetting("JEERA_TOKEN_STORE"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %v", err)
+	}
+	return filepath.Join(dir, "jeera", "tokens.json"), nil
+}
+
+// loadTokenStore reads the token store. A missing file yields an empty store.
+func loadTokenStore() (*tokenStore, error) {
+	path, err := tokenStorePath()
+	if err != nil {
+		return nil, err
+	}
+
+	store := &tokenStore{Entries: make(map[string]tokenStoreEntry), path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token store: %v", err)
+	}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("failed to parse token store %s: %v", path, err)
+	}
+	if store.Entries == nil {
+		store.Entries = make(map[string]tokenStoreEntry)
+	}
+	return store, nil
+}
+
+// save writes the token store, readable by the owner only
+func (s *tokenStore) save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode token store: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create token store directory: %v", err)
+	}
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write token store: %v", err)
+	}
+	return nil
+}
+
+// URLs returns the base URLs with a stored token in alphabetical order
+func (s *tokenStore) URLs() []string {
+	urls := make([]string, 0, len(s.Entries))
+	for u := range s.Entries {
+		urls = append(urls, u)
+	}
+	sort.Strings(urls)
+	return urls
+}
+
+// tokenStoreKey normalises a base URL so that a trailing slash does not matter
+func tokenStoreKey(baseURL string) string {
+	return strings.TrimRight(baseURL, "/")
+}
+
+// tokenStoreCipher derives the AES-GCM cipher for an entry from the passphrase and salt
+func tokenStoreCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, tokenStoreIterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Set encrypts token for baseURL with the passphrase, replacing any previous entry
+func (s *tokenStore) Set(baseURL, kind, token, passphrase string) error {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %v", err)
+	}
+	gcm, err := tokenStoreCipher(passphrase, salt)
+	if err != nil {
+		return fmt.Errorf("failed to derive key: %v", err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %v", err)
+	}
+
+	key := tokenStoreKey(baseURL)
+	s.Entries[key] = tokenStoreEntry{
+		Kind:       kind,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, []byte(token), []byte(key)),
+	}
+	return nil
+}
+
+// Get decrypts the token stored for baseURL. ok is false when there is none.
+func (s *tokenStore) Get(baseURL, passphrase string) (token, kind string, ok bool, err error) {
+	key := tokenStoreKey(baseURL)
+	entry, ok := s.Entries[key]
+	if !ok {
+		return "", "", false, nil
+	}
+
+	gcm, err := tokenStoreCipher(passphrase, entry.Salt)
+	if err != nil {
+		return "", "", true, fmt.Errorf("failed to derive key: %v", err)
+	}
+	plain, err := gcm.Open(nil, entry.Nonce, entry.Ciphertext, []byte(key))
+	if err != nil {
+		return "", "", true, errors.New("failed to unlock token store: wrong passphrase or corrupted entry")
+	}
+	return string(plain), entry.Kind, true, nil
+}
+
+// readSecret asks for a secret on the controlling terminal without echoing it, so
+// `... | jeera comment add` still works. Piped stdin is never read as a secret.
+// Ctrl-C abandons the prompt: the terminal keeps sending SIGINT while echo is off,
+// and the signal context of the command would otherwise swallow it.
+func readSecret(prompt string) (string, error) {
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return "", errors.New("cannot read secret: no terminal available")
+	}
+	defer tty.Close()
+	fd := int(tty.Fd())
+	if !term.IsTerminal(fd) {
+		return "", errors.New("cannot read secret: no terminal available")
+	}
+	state, err := term.GetState(fd)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret: %v", err)
+	}
+
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupts)
+
+	type answer struct {
+		secret []byte
+		err    error
+	}
+	answers := make(chan answer, 1)
+
+	fmt.Fprint(os.Stderr, prompt)
+	go func() {
+		secret, err := term.ReadPassword(fd)
+		answers <- answer{secret, err}
+	}()
+
+	select {
+	case a := <-answers:
+		fmt.Fprintln(os.Stderr)
+		if a.err != nil {
+			return "", fmt.Errorf("failed to read secret: %v", a.err)
+		}
+		return string(a.secret), nil
+	case <-interrupts:
+		term.Restore(fd, state)
+		fmt.Fprintln(os.Stderr)
+		return "", &interruptedError{err: errors.New("secret prompt cancelled")}
+	}
+}
+
+// storedToken returns the token kept in the encrypted store for baseURL, asking for
+// the passphrase only when the store actually has an entry for it
+func storedToken(baseURL string) (token, kind string, err error) {
+	store, err := loadTokenStore()
+	if err != nil {
+		return "", "", err
+	}
+	if _, ok := store.Entries[tokenStoreKey(baseURL)]; !ok {
+		return "", "", nil
+	}
+
+	passphrase, err := readSecret(fmt.Sprintf("Token store passphrase for %s: ", tokenStoreKey(baseURL)))
+	if err != nil {
+		return "", "", err
+	}
+	token, kind, _, err = store.Get(baseURL, passphrase)
+	return token, kind, err
+}
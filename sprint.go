@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// sprintFields returns the fields needed to render the active sprint view
+func sprintFields(ctx context.Context, client *JiraClient) ([]string, error) {
+	storyPoints, err := client.FieldID(ctx, client.config.StoryPointsField)
+	if err != nil {
+		return nil, err
+	}
+	return []string{"summary", "status", "assignee", storyPoints}, nil
+}
+
+// statusGroup holds the issues of a sprint that share the same status
+type statusGroup struct {
+	Status      string
+	Issues      []Issue
+	StoryPoints float32
+}
+
+// activeSprintJQL builds the query for the open sprint issues of a user.
+// An empty user means the user that owns the credentials.
+func activeSprintJQL(user string) string {
+	assignee := "currentUser()"
+	if user != "" {
+		assignee = quoteJQL(user)
+	}
+	return fmt.Sprintf("sprint in openSprints() AND assignee = %s ORDER BY status ASC, key ASC", assignee)
+}
+
+// quoteJQL wraps a value in double quotes, escaping characters JQL treats specially
+func quoteJQL(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
+
+// groupByStatus groups issues by status name, keeping the order in which statuses first appear
+func groupByStatus(issues []Issue) []statusGroup {
+	var groups []statusGroup
+	index := make(map[string]int)
+
+	for _, issue := range issues {
+		status := "No Status"
+		if issue.Fields.Status != nil {
+			status = issue.Fields.Status.Name
+		}
+
+		i, ok := index[status]
+		if !ok {
+			i = len(groups)
+			index[status] = i
+			groups = append(groups, statusGroup{Status: status})
+		}
+		groups[i].Issues = append(groups[i].Issues, issue)
+		groups[i].StoryPoints += issue.Fields.Points()
+	}
+
+	return groups
+}
+
+// printSprintBoard prints the sprint issues grouped by status with story point totals
+func printSprintBoard(groups []statusGroup) {
+	var totalIssues int
+	var totalPoints float32
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, group := range groups {
+		fmt.Fprintf(w, "\n%s (%d issues, %g pts)\n", group.Status, len(group.Issues), group.StoryPoints)
+		for _, issue := range group.Issues {
+			fmt.Fprintf(w, "  %s\t%g\t%s\n", issue.Key, issue.Fields.Points(), issue.Fields.Summary)
+		}
+		totalIssues += len(group.Issues)
+		totalPoints += group.StoryPoints
+	}
+	w.Flush()
+
+	fmt.Printf("\nTotal: %d issues, %g pts\n", totalIssues, totalPoints)
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// TimesheetRow is the time one user logged on one issue during a week
+type TimesheetRow struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	// Days holds the seconds logged per day, Monday first
+	Days  [7]int `json:"days"`
+	Total int    `json:"total"`
+}
+
+// Timesheet is a week of logged time, one row per issue
+type Timesheet struct {
+	User   string         `json:"user"`
+	Monday time.Time      `json:"monday"`
+	Rows   []TimesheetRow `json:"rows"`
+}
+
+// parseISOWeek returns the Monday of an ISO 8601 week such as "2026-W42", at midnight local time.
+// An empty value is the current week.
+func parseISOWeek(value string) (time.Time, error) {
+	if value == "" {
+		now := time.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		return today.AddDate(0, 0, -weekdayIndex(today)), nil
+	}
+
+	yearPart, weekPart, ok := strings.Cut(strings.ToUpper(value), "-W")
+	year, yerr := strconv.Atoi(yearPart)
+	week, werr := strconv.Atoi(weekPart)
+	if !ok || yerr != nil || werr != nil || week < 1 || week > 53 {
+		return time.Time{}, fmt.Errorf("invalid week %q, expected e.g. 2026-W42", value)
+	}
+
+	// January 4th is always in week 1
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.Local)
+	monday := jan4.AddDate(0, 0, -weekdayIndex(jan4)+(week-1)*7)
+	if y, w := monday.ISOWeek(); y != year || w != week {
+		return time.Time{}, fmt.Errorf("%d has no week %d", year, week)
+	}
+	return monday, nil
+}
+
+// weekdayIndex numbers the days of the week from Monday (0) to Sunday (6)
+func weekdayIndex(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
+
+// worklogBy reports whether a worklog was logged by the user with the given name,
+// matching the user name, key or account ID
+func worklogBy(w *Worklog, user string) bool {
+	if w.Author == nil {
+		return false
+	}
+	return strings.EqualFold(w.Author.Name, user) || w.Author.Key == user || w.Author.AccountID == user
+}
+
+// GetTimesheet collects the time user logged during the week starting on monday
+func (client *JiraClient) GetTimesheet(ctx context.Context, user string, monday time.Time) (*Timesheet, error) {
+	sunday := monday.AddDate(0, 0, 6)
+	jql := fmt.Sprintf("worklogAuthor = %s AND worklogDate >= %s AND worklogDate <= %s ORDER BY key ASC",
+		quoteJQL(user), quoteJQL(monday.Format("2006-01-02")), quoteJQL(sunday.Format("2006-01-02")))
+
+	issues, err := client.SearchIssues(ctx, jql, []string{"summary"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := &Timesheet{User: user, Monday: monday}
+
+	for _, issue := range issues {
+		worklogs, err := client.GetWorklogs(ctx, issue.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the worklogs of %s: %w", issue.Key, err)
+		}
+
+		row := timesheetRow(&issue, worklogs, user, monday)
+
+		// worklogDate is matched in the server's time zone, so an issue found
+		// near the edges of the week may have no work in it here
+		if row.Total > 0 {
+			sheet.Rows = append(sheet.Rows, row)
+		}
+	}
+
+	return sheet, nil
+}
+
+// timesheetRow sums the worklogs of user on issue during the week starting on monday
+// per local day
+func timesheetRow(issue *Issue, worklogs []Worklog, user string, monday time.Time) TimesheetRow {
+	end := monday.AddDate(0, 0, 7)
+	row := TimesheetRow{Key: issue.Key, Summary: issue.Fields.Summary}
+	for i := range worklogs {
+		w := &worklogs[i]
+		started := w.Started.In(time.Local)
+		if !worklogBy(w, user) || started.Before(monday) || !started.Before(end) {
+			continue
+		}
+		row.Days[weekdayIndex(started)] += w.TimeSpentSeconds
+		row.Total += w.TimeSpentSeconds
+	}
+	return row
+}
+
+// dayTotals sums the seconds logged per day over every row, plus the week total
+func (sheet *Timesheet) dayTotals() ([7]int, int) {
+	var days [7]int
+	total := 0
+	for _, row := range sheet.Rows {
+		for d, seconds := range row.Days {
+			days[d] += seconds
+		}
+		total += row.Total
+	}
+	return days, total
+}
+
+// formatHours formats seconds as decimal hours rounded to two places, e.g. "1.5"; zero is empty
+func formatHours(seconds int) string {
+	if seconds == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(math.Round(float64(seconds)/36)/100, 'f', -1, 64)
+}
+
+// printTimesheet prints the week as a grid of hours per issue and day
+func printTimesheet(sheet *Timesheet) {
+	_, week := sheet.Monday.ISOWeek()
+	fmt.Printf("Timesheet of %s, week %d (%s to %s)\n\n", sheet.User, week,
+		sheet.Monday.Format("2006-01-02"), sheet.Monday.AddDate(0, 0, 6).Format("2006-01-02"))
+
+	if len(sheet.Rows) == 0 {
+		fmt.Println("No work logged this week.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	header := []string{"Issue"}
+	for d := 0; d < 7; d++ {
+		header = append(header, sheet.Monday.AddDate(0, 0, d).Format("Mon 02"))
+	}
+	header = append(header, "Total")
+	fmt.Fprintln(w, strings.Join(header, "\t")+"\t")
+
+	for _, row := range sheet.Rows {
+		cells := []string{row.Key}
+		for _, seconds := range row.Days {
+			cells = append(cells, orDash(formatHours(seconds)))
+		}
+		cells = append(cells, formatHours(row.Total), "  "+row.Summary)
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+
+	days, total := sheet.dayTotals()
+	cells := []string{"Total"}
+	for _, seconds := range days {
+		cells = append(cells, orDash(formatHours(seconds)))
+	}
+	cells = append(cells, formatHours(total), "")
+	fmt.Fprintln(w, strings.Join(cells, "\t"))
+	w.Flush()
+}
+
+// orDash returns s, or "-" when it is empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// timesheetRecords renders a timesheet with one record per issue and hours as decimals
+func timesheetRecords(sheet *Timesheet) records {
+	columns := []string{"key", "summary"}
+	for d := 0; d < 7; d++ {
+		columns = append(columns, sheet.Monday.AddDate(0, 0, d).Format("2006-01-02"))
+	}
+	columns = append(columns, "total")
+
+	r := records{
+		items:   []interface{}{sheet},
+		single:  true,
+		columns: columns,
+		table:   func() { printTimesheet(sheet) },
+	}
+	for _, row := range sheet.Rows {
+		cells := []string{row.Key, row.Summary}
+		for _, seconds := range row.Days {
+			cells = append(cells, formatHours(seconds))
+		}
+		r.rows = append(r.rows, append(cells, formatHours(row.Total)))
+	}
+	return r
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseISOWeek(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "2026-W42", want: time.Date(2026, time.October, 12, 0, 0, 0, 0, time.Local)},
+		{value: "2026-w42", want: time.Date(2026, time.October, 12, 0, 0, 0, 0, time.Local)},
+		// week 1 is the week with January 4th, so it can start in the previous year
+		{value: "2026-W01", want: time.Date(2025, time.December, 29, 0, 0, 0, 0, time.Local)},
+		{value: "2021-W01", want: time.Date(2021, time.January, 4, 0, 0, 0, 0, time.Local)},
+		{value: "2020-W53", want: time.Date(2020, time.December, 28, 0, 0, 0, 0, time.Local)},
+		{value: "2026-W53", want: time.Date(2026, time.December, 28, 0, 0, 0, 0, time.Local)},
+		{value: "2025-W53", wantErr: true},
+		{value: "2026-W00", wantErr: true},
+		{value: "2026-W54", wantErr: true},
+		{value: "2026W42", wantErr: true},
+		{value: "2026-42", wantErr: true},
+		{value: "W42", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseISOWeek(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseISOWeek(%q) = %v, want an error", tt.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseISOWeek(%q) failed: %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseISOWeek(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseISOWeekCurrent(t *testing.T) {
+	monday, err := parseISOWeek("")
+	if err != nil {
+		t.Fatalf("parseISOWeek(\"\") failed: %v", err)
+	}
+	if monday.Weekday() != time.Monday || monday.Hour() != 0 || monday.Minute() != 0 {
+		t.Errorf("parseISOWeek(\"\") = %v, want a Monday at midnight", monday)
+	}
+	if now := time.Now(); now.Before(monday) || !now.Before(monday.AddDate(0, 0, 7)) {
+		t.Errorf("parseISOWeek(\"\") = %v, want the week of %v", monday, now)
+	}
+}
+
+func TestWeekdayIndex(t *testing.T) {
+	monday := time.Date(2026, time.October, 12, 0, 0, 0, 0, time.Local)
+	for i := 0; i < 7; i++ {
+		if got := weekdayIndex(monday.AddDate(0, 0, i)); got != i {
+			t.Errorf("weekdayIndex(%v) = %d, want %d", monday.AddDate(0, 0, i).Weekday(), got, i)
+		}
+	}
+}
+
+func TestTimesheetRow(t *testing.T) {
+	monday := time.Date(2026, time.October, 12, 0, 0, 0, 0, time.Local)
+	me := &User{Name: "jdoe", Key: "JIRAUSER1"}
+	other := &User{Name: "someone"}
+	at := func(days int, hour, minute int) time.Time {
+		return monday.AddDate(0, 0, days).Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	worklogs := []Worklog{
+		{Author: me, Started: at(0, 0, 0), TimeSpentSeconds: 3600},                    // Monday at midnight
+		{Author: me, Started: at(0, 9, 0), TimeSpentSeconds: 1800},                    // Monday
+		{Author: me, Started: at(2, 14, 0), TimeSpentSeconds: 7200},                   // Wednesday
+		{Author: me, Started: at(6, 23, 30), TimeSpentSeconds: 900},                   // Sunday, last minutes of the week
+		{Author: me, Started: at(-1, 23, 59), TimeSpentSeconds: 600},                  // previous Sunday
+		{Author: me, Started: at(7, 0, 0), TimeSpentSeconds: 600},                     // next Monday
+		{Author: other, Started: at(1, 10, 0), TimeSpentSeconds: 3600},                // someone else
+		{Author: nil, Started: at(1, 10, 0), TimeSpentSeconds: 3600},                  // unknown author
+		{Author: &User{Key: "JIRAUSER1"}, Started: at(4, 8, 0), TimeSpentSeconds: 60}, // by key
+	}
+	issue := &Issue{Key: "GTJ-1", Fields: IssueFields{Summary: "Login page"}}
+
+	row := timesheetRow(issue, worklogs, "JDOE", monday)
+	row2 := timesheetRow(issue, worklogs, "JIRAUSER1", monday)
+
+	want := [7]int{5400, 0, 7200, 0, 0, 0, 900}
+	if row.Key != "GTJ-1" || row.Summary != "Login page" {
+		t.Errorf("row is %s %q, want GTJ-1 \"Login page\"", row.Key, row.Summary)
+	}
+	if row.Days != want {
+		t.Errorf("days = %v, want %v", row.Days, want)
+	}
+	if row.Total != 5400+7200+900 {
+		t.Errorf("total = %d, want %d", row.Total, 5400+7200+900)
+	}
+
+	// the user key matches every worklog of the user, the name only those carrying it
+	want2 := [7]int{5400, 0, 7200, 0, 60, 0, 900}
+	if row2.Days != want2 {
+		t.Errorf("days by key = %v, want %v", row2.Days, want2)
+	}
+}
+
+func TestTimesheetDayTotals(t *testing.T) {
+	sheet := &Timesheet{Rows: []TimesheetRow{
+		{Key: "GTJ-1", Days: [7]int{3600, 0, 1800}, Total: 5400},
+		{Key: "GTJ-2", Days: [7]int{0, 0, 1800, 0, 0, 0, 600}, Total: 2400},
+	}}
+
+	days, total := sheet.dayTotals()
+	if want := [7]int{3600, 0, 3600, 0, 0, 0, 600}; days != want {
+		t.Errorf("day totals = %v, want %v", days, want)
+	}
+	if total != 7800 {
+		t.Errorf("week total = %d, want 7800", total)
+	}
+}
+
+func TestFormatHours(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    string
+	}{
+		{0, ""},
+		{3600, "1"},
+		{5400, "1.5"},
+		{900, "0.25"},
+		{1200, "0.33"},
+		{60, "0.02"},
+	}
+
+	for _, tt := range tests {
+		if got := formatHours(tt.seconds); got != tt.want {
+			t.Errorf("formatHours(%d) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RequiredFields returns the screen fields JIRA requires for the transition, sorted by name
+func (t *Transition) RequiredFields() []FieldMeta {
+	var required []FieldMeta
+	for _, meta := range t.Fields {
+		if meta.Required {
+			required = append(required, meta)
+		}
+	}
+	sort.Slice(required, func(i, j int) bool { return required[i].Name < required[j].Name })
+	return required
+}
+
+// Field returns the screen field of the transition matching a field ID or display name
+func (t *Transition) Field(nameOrID string) (*FieldMeta, bool) {
+	if meta, ok := t.Fields[nameOrID]; ok {
+		return &meta, true
+	}
+	for _, meta := range t.Fields {
+		if strings.EqualFold(meta.Name, nameOrID) {
+			return &meta, true
+		}
+	}
+	return nil, false
+}
+
+// transitionFields converts `Name=value` assignments and a resolution into the fields
+// of a transition request. Names are matched against the transition screen first,
+// so allowed values are checked locally, and then against every field of the instance.
+func (client *JiraClient) transitionFields(ctx context.Context, t *Transition, assignments map[string]string, resolution string) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+
+	if resolution != "" {
+		assignments["resolution"] = resolution
+	}
+
+	for name, value := range assignments {
+		if meta, ok := t.Field(name); ok {
+			v, err := metaFieldValue(*meta, value)
+			if err != nil {
+				return nil, err
+			}
+			fields[meta.FieldID] = v
+			continue
+		}
+
+		field, err := client.LookupField(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		v, err := fieldValue(field.Schema, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", field.Name, err)
+		}
+		fields[field.ID] = v
+	}
+
+	return fields, nil
+}
+
+// unsetRequiredFields returns the required screen fields of a transition that are
+// neither given in fields nor already set on the issue
+func (client *JiraClient) unsetRequiredFields(ctx context.Context, issueIDOrKey string, t *Transition, fields map[string]interface{}) ([]FieldMeta, error) {
+	var candidates []FieldMeta
+	for _, meta := range t.RequiredFields() {
+		if _, ok := fields[meta.FieldID]; !ok {
+			candidates = append(candidates, meta)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	// fields such as the summary are often required on a screen but already filled in
+	issue, err := client.GetIssue(ctx, issueIDOrKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []FieldMeta
+	for _, meta := range candidates {
+		if isEmptyValue(issue.Fields.Raw[meta.FieldID]) {
+			missing = append(missing, meta)
+		}
+	}
+	return missing, nil
+}
+
+// isEmptyValue reports whether a raw field value is absent, null or empty
+func isEmptyValue(raw json.RawMessage) bool {
+	switch strings.TrimSpace(string(raw)) {
+	case "", "null", `""`, "[]", "{}":
+		return true
+	}
+	return false
+}
+
+// describeFieldMeta names a field and, when JIRA restricts it, its allowed values
+func describeFieldMeta(meta FieldMeta) string {
+	if len(meta.AllowedValues) == 0 {
+		return fmt.Sprintf("%s (%s)", meta.Name, schemaString(meta.Schema))
+	}
+	allowed := make([]string, 0, len(meta.AllowedValues))
+	for _, v := range meta.AllowedValues {
+		allowed = append(allowed, formatFieldValue(v))
+	}
+	return fmt.Sprintf("%s (one of: %s)", meta.Name, strings.Join(allowed, ", "))
+}
+
+// promptTransitionFields asks for each required field in turn. Allowed values are listed
+// with numbers and can be picked by number or typed; an empty answer skips the field.
+func promptTransitionFields(scanner *bufio.Scanner, required []FieldMeta) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+
+	for _, meta := range required {
+		if len(meta.AllowedValues) > 0 {
+			fmt.Printf("%s is required:\n", meta.Name)
+			for i, v := range meta.AllowedValues {
+				fmt.Printf("  %d. %s\n", i+1, formatFieldValue(v))
+			}
+			fmt.Printf("%s: ", meta.Name)
+		} else {
+			fmt.Printf("%s (required, %s): ", meta.Name, schemaString(meta.Schema))
+		}
+		scanner.Scan()
+		input := strings.TrimSpace(scanner.Text())
+		if input == "" {
+			continue
+		}
+
+		// a number picks from the list of allowed values
+		var choice int
+		if _, err := fmt.Sscanf(input, "%d", &choice); err == nil && choice >= 1 && choice <= len(meta.AllowedValues) {
+			input = formatFieldValue(meta.AllowedValues[choice-1])
+		}
+
+		value, err := metaFieldValue(meta, input)
+		if err != nil {
+			return nil, err
+		}
+		fields[meta.FieldID] = value
+	}
+
+	return fields, nil
+}
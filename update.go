@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Operation is a single JIRA update verb applied to a field, e.g. {"add": "backend"}
+type Operation map[string]interface{}
+
+// Update verbs understood by the issue edit endpoint. JIRA also has "edit", which
+// only applies to existing comments and worklogs; jeera changes those through their
+// own endpoints and never builds it.
+const (
+	opSet    = "set"
+	opAdd    = "add"
+	opRemove = "remove"
+)
+
+// IssueUpdate describes the changes to make to an issue. A nil pointer or a zero
+// ListChange leaves the field as it is, so only what is set is sent.
+type IssueUpdate struct {
+	Summary            *string
+	Description        *string
+	AcceptanceCriteria *string
+	StoryPoints        *float32
+	Priority           *string // by name
+
+	Labels      ListChange
+	Components  ListChange
+	FixVersions ListChange
+	Versions    ListChange
+
+	// Clear empties fields, by ID or display name
+	Clear []string
+
+	// Operations holds further operations, keyed by field ID
+	Operations map[string][]Operation
+}
+
+// IsZero reports whether the update changes nothing
+func (u *IssueUpdate) IsZero() bool {
+	return u.Summary == nil && u.Description == nil && u.AcceptanceCriteria == nil &&
+		u.StoryPoints == nil && u.Priority == nil &&
+		u.Labels.IsZero() && u.Components.IsZero() && u.FixVersions.IsZero() && u.Versions.IsZero() &&
+		len(u.Clear) == 0 && len(u.Operations) == 0
+}
+
+// updateOperations converts an update into operations keyed by field ID,
+// looking up the IDs of the custom fields it touches
+func (client *JiraClient) updateOperations(ctx context.Context, u *IssueUpdate) (map[string][]Operation, error) {
+	update := listOperations(map[string]ListChange{
+		fieldLabels:      u.Labels,
+		fieldComponents:  u.Components,
+		fieldFixVersions: u.FixVersions,
+		fieldVersions:    u.Versions,
+	})
+
+	if u.Summary != nil {
+		update["summary"] = []Operation{{opSet: *u.Summary}}
+	}
+	if u.Description != nil {
+		update["description"] = []Operation{{opSet: *u.Description}}
+	}
+	if u.Priority != nil {
+		update["priority"] = []Operation{{opSet: map[string]string{"name": *u.Priority}}}
+	}
+	if u.AcceptanceCriteria != nil {
+		id, err := client.FieldID(ctx, client.config.AcceptanceCriteriaField)
+		if err != nil {
+			return nil, err
+		}
+		update[id] = []Operation{{opSet: *u.AcceptanceCriteria}}
+	}
+	if u.StoryPoints != nil {
+		id, err := client.FieldID(ctx, client.config.StoryPointsField)
+		if err != nil {
+			return nil, err
+		}
+		update[id] = []Operation{{opSet: *u.StoryPoints}}
+	}
+	for _, name := range u.Clear {
+		field, err := client.clearedField(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		update[field.ID] = []Operation{{opSet: clearedValue(field)}}
+	}
+	for id, ops := range u.Operations {
+		update[id] = append(update[id], ops...)
+	}
+
+	return update, nil
+}
+
+// clearedField looks up a field to clear by display name or ID
+func (client *JiraClient) clearedField(ctx context.Context, name string) (*Field, error) {
+	field, err := client.LookupField(ctx, name)
+	if err != nil {
+		// like FieldID, custom field IDs are trusted even when discovery fails
+		if !strings.HasPrefix(name, "customfield_") {
+			return nil, err
+		}
+		field = &Field{ID: name}
+	}
+	return field, nil
+}
+
+// clearConflict returns the first field of u.Clear that the update also sets, or "".
+// Fields are compared by ID, so a display name and an ID of the same field conflict.
+func (client *JiraClient) clearConflict(ctx context.Context, u IssueUpdate) (string, error) {
+	clear := u.Clear
+	u.Clear = nil
+	set, err := client.updateOperations(ctx, &u)
+	if err != nil {
+		return "", err
+	}
+
+	for _, name := range clear {
+		field, err := client.clearedField(ctx, name)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := set[field.ID]; ok {
+			return name, nil
+		}
+	}
+	return "", nil
+}
+
+// ListChange describes a change to a multi-value field such as labels or components.
+// Set replaces the whole list when it is not nil, an empty Set clears it. Otherwise
+// Add and Remove change single values and leave the rest of the list alone.
+type ListChange struct {
+	Set    []string
+	Add    []string
+	Remove []string
+}
+
+// IsZero reports whether the change leaves the field untouched
+func (c ListChange) IsZero() bool {
+	return c.Set == nil && len(c.Add) == 0 && len(c.Remove) == 0
+}
+
+// operations returns the update operations for the change, converting each name with value
+func (c ListChange) operations(value func(name string) interface{}) []Operation {
+	if c.Set != nil {
+		items := make([]interface{}, 0, len(c.Set))
+		for _, name := range c.Set {
+			items = append(items, value(name))
+		}
+		return []Operation{{opSet: items}}
+	}
+
+	var ops []Operation
+	for _, name := range c.Add {
+		ops = append(ops, Operation{opAdd: value(name)})
+	}
+	for _, name := range c.Remove {
+		ops = append(ops, Operation{opRemove: value(name)})
+	}
+	return ops
+}
+
+// List fields that can be changed with a ListChange
+const (
+	fieldLabels      = "labels"
+	fieldComponents  = "components"
+	fieldFixVersions = "fixVersions"
+	fieldVersions    = "versions"
+)
+
+// listItem converts a name into the value JIRA expects in a list field:
+// labels are plain strings, components and versions are referenced by name
+func listItem(field string) func(name string) interface{} {
+	if field == fieldLabels {
+		return func(name string) interface{} { return name }
+	}
+	return func(name string) interface{} { return map[string]string{"name": name} }
+}
+
+// componentNames returns the names of components
+func componentNames(components []Component) []string {
+	names := make([]string, 0, len(components))
+	for _, c := range components {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// versionNames returns the names of versions
+func versionNames(versions []Version) []string {
+	names := make([]string, 0, len(versions))
+	for _, v := range versions {
+		names = append(names, v.Name)
+	}
+	return names
+}
+
+// validateLabels rejects labels JIRA would refuse, which cannot contain spaces
+func validateLabels(labels []string) error {
+	for _, label := range labels {
+		if label == "" || strings.ContainsAny(label, " \t") {
+			return fmt.Errorf("invalid label %q, labels cannot be empty or contain spaces", label)
+		}
+	}
+	return nil
+}
+
+// EditIssue sends update operations for an issue, keyed by field ID. Only set, add
+// and remove are supported: comments and worklogs, the fields the edit verb works on,
+// are changed with UpdateComment and UpdateWorklog instead.
+func (client *JiraClient) EditIssue(ctx context.Context, issueIDOrKey string, update map[string][]Operation) error {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s", issueIDOrKey)
+
+	request := map[string]interface{}{"update": update}
+
+	if *DEBUGflag {
+		fmt.Printf("EditIssue request: %+v\n", request)
+	}
+
+	resp, err := client.makeRequest(ctx, "PUT", endpoint, request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return client.apiError(resp, "edit issue")
+	}
+
+	return nil
+}
+
+// clearedValue returns the value that empties a field: an empty list for
+// multi-value fields and null for everything else
+func clearedValue(field *Field) interface{} {
+	if field.Schema.Type == "array" {
+		return []interface{}{}
+	}
+	return nil
+}
+
+// listOperations converts list changes, keyed by field, into update operations
+func listOperations(changes map[string]ListChange) map[string][]Operation {
+	update := make(map[string][]Operation)
+	for field, change := range changes {
+		if !change.IsZero() {
+			update[field] = change.operations(listItem(field))
+		}
+	}
+	return update
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// User represents a JIRA user as returned by /rest/api/2/myself
+type User struct {
+	Name         string `json:"name"`
+	Key          string `json:"key,omitempty"`
+	AccountID    string `json:"accountId,omitempty"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress,omitempty"`
+	TimeZone     string `json:"timeZone,omitempty"`
+	Active       bool   `json:"active"`
+}
+
+// CurrentUser returns the user owning the configured credentials.
+// The result is fetched once and reused for the lifetime of the client.
+func (client *JiraClient) CurrentUser(ctx context.Context) (*User, error) {
+	if client.currentUser != nil {
+		return client.currentUser, nil
+	}
+
+	resp, err := client.makeRequest(ctx, "GET", "/rest/api/2/myself", nil)
+	if err != nil {
+		// JIRA answered but turned the authenticator away, e.g. a failed session login
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			return nil, fmt.Errorf("authentication failed: %w", err)
+		}
+		return nil, fmt.Errorf("JIRA instance %s is unreachable: %v", client.config.BaseURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err := client.apiError(resp, "get current user")
+		if IsUnauthorized(err) || IsForbidden(err) {
+			return nil, fmt.Errorf("authentication failed, the token is invalid or has expired: %w", err)
+		}
+		return nil, err
+	}
+
+	var user User
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	client.currentUser = &user
+	return client.currentUser, nil
+}
+
+// resolveUsername maps "me" to the username of the current user and returns anything else unchanged
+func (client *JiraClient) resolveUsername(ctx context.Context, name string) (string, error) {
+	if name != "me" {
+		return name, nil
+	}
+
+	user, err := client.CurrentUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	return user.Name, nil
+}
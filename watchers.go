@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// watchingJQL finds every issue the current user watches
+const watchingJQL = "watcher = currentUser() ORDER BY updated DESC"
+
+// Watchers represents the watchers of a JIRA issue
+type Watchers struct {
+	WatchCount int    `json:"watchCount"`
+	IsWatching bool   `json:"isWatching"` // whether the current user watches the issue
+	Watchers   []User `json:"watchers"`
+}
+
+// GetWatchers returns the watchers of an issue
+func (client *JiraClient) GetWatchers(ctx context.Context, issueIDOrKey string) (*Watchers, error) {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/watchers", issueIDOrKey)
+
+	resp, err := client.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.apiError(resp, "get watchers")
+	}
+
+	var watchers Watchers
+	if err := json.NewDecoder(resp.Body).Decode(&watchers); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	return &watchers, nil
+}
+
+// AddWatcher adds a user, by user name, to the watchers of an issue
+func (client *JiraClient) AddWatcher(ctx context.Context, issueIDOrKey, username string) error {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/watchers", issueIDOrKey)
+
+	// the body is the bare user name as a JSON string
+	resp, err := client.makeRequest(ctx, "POST", endpoint, username)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return client.apiError(resp, "add watcher")
+	}
+
+	return nil
+}
+
+// RemoveWatcher removes a user, by user name, from the watchers of an issue
+func (client *JiraClient) RemoveWatcher(ctx context.Context, issueIDOrKey, username string) error {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/watchers?username=%s", issueIDOrKey, url.QueryEscape(username))
+
+	resp, err := client.makeRequest(ctx, "DELETE", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return client.apiError(resp, "remove watcher")
+	}
+
+	return nil
+}
+
+// setWatching adds the current user to the watchers of an issue, or removes them when watch is false
+func (client *JiraClient) setWatching(ctx context.Context, issueIDOrKey string, watch bool) error {
+	username, err := client.resolveUsername(ctx, "me")
+	if err != nil {
+		return err
+	}
+	if watch {
+		return client.AddWatcher(ctx, issueIDOrKey, username)
+	}
+	return client.RemoveWatcher(ctx, issueIDOrKey, username)
+}
+
+// addAutoWatchers adds the configured watchers to a newly created issue of one of the
+// configured types and returns one error per watcher that could not be added. The
+// issue exists at this point, so callers only report them.
+func (client *JiraClient) addAutoWatchers(ctx context.Context, issueKey, issueType string) []error {
+	if len(client.config.AutoWatchers) == 0 || !containsFold(client.config.AutoWatchTypes, issueType) {
+		return nil
+	}
+
+	var errs []error
+	for _, username := range client.config.AutoWatchers {
+		if err := client.AddWatcher(ctx, issueKey, username); err != nil {
+			errs = append(errs, fmt.Errorf("could not add %s as watcher of %s: %v", username, issueKey, err))
+		}
+	}
+	return errs
+}
+
+// containsFold reports whether list contains value, ignoring case
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// printWatchers prints the watchers of an issue
+func printWatchers(watchers *Watchers) {
+	fmt.Printf("Watchers: %d\n", watchers.WatchCount)
+	for _, u := range watchers.Watchers {
+		fmt.Printf("  %-20s %s\n", u.Name, userName(&u))
+	}
+	if watchers.IsWatching {
+		fmt.Println("You are watching this issue.")
+	}
+}
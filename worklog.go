@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Worklog represents time logged against a JIRA issue
+type Worklog struct {
+	ID               string    `json:"id,omitempty"`
+	IssueID          string    `json:"issueId,omitempty"`
+	Author           *User     `json:"author,omitempty"`
+	UpdateAuthor     *User     `json:"updateAuthor,omitempty"`
+	Comment          string    `json:"comment"`
+	Started          time.Time `json:"started"`
+	TimeSpent        string    `json:"timeSpent,omitempty"`
+	TimeSpentSeconds int       `json:"timeSpentSeconds"`
+	Created          time.Time `json:"created"`
+	Updated          time.Time `json:"updated"`
+}
+
+// UnmarshalJSON decodes a worklog, parsing the JIRA timestamps
+func (w *Worklog) UnmarshalJSON(data []byte) error {
+	type plain Worklog
+	var raw struct {
+		*plain
+		Started string `json:"started"`
+		Created string `json:"created"`
+		Updated string `json:"updated"`
+	}
+	raw.plain = (*plain)(w)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	if w.Started, err = parseJiraTime(raw.Started); err != nil {
+		return fmt.Errorf("worklog %s: %v", w.ID, err)
+	}
+	if w.Created, err = parseJiraTime(raw.Created); err != nil {
+		return fmt.Errorf("worklog %s: %v", w.ID, err)
+	}
+	if w.Updated, err = parseJiraTime(raw.Updated); err != nil {
+		return fmt.Errorf("worklog %s: %v", w.ID, err)
+	}
+	return nil
+}
+
+// AuthorName returns the display name of the user who logged the work
+func (w *Worklog) AuthorName() string {
+	return userName(w.Author)
+}
+
+// worklogsResponse represents a page of the worklogs of an issue
+type worklogsResponse struct {
+	StartAt    int       `json:"startAt"`
+	MaxResults int       `json:"maxResults"`
+	Total      int       `json:"total"`
+	Worklogs   []Worklog `json:"worklogs"`
+}
+
+// GetWorklogs returns every worklog of an issue, oldest first
+func (client *JiraClient) GetWorklogs(ctx context.Context, issueIDOrKey string) ([]Worklog, error) {
+	var worklogs []Worklog
+
+	for {
+		endpoint := fmt.Sprintf("/rest/api/2/issue/%s/worklog?startAt=%d", issueIDOrKey, len(worklogs))
+
+		resp, err := client.makeRequest(ctx, "GET", endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			err := client.apiError(resp, "get worklogs")
+			resp.Body.Close()
+			return nil, err
+		}
+
+		var page worklogsResponse
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode response: %v", err)
+		}
+
+		worklogs = append(worklogs, page.Worklogs...)
+
+		// JIRA Server returns every worklog at once, Cloud pages them
+		if len(page.Worklogs) == 0 || len(worklogs) >= page.Total {
+			break
+		}
+	}
+
+	return worklogs, nil
+}
+
+// worklogRequest builds the body of a worklog create or update request.
+// A zero started or seconds leaves that value unchanged on update.
+func worklogRequest(started time.Time, seconds int, comment string) map[string]interface{} {
+	request := map[string]interface{}{}
+	if !started.IsZero() {
+		request["started"] = started.Format(jiraTimeLayout)
+	}
+	if seconds > 0 {
+		request["timeSpentSeconds"] = seconds
+	}
+	if comment != "" {
+		request["comment"] = comment
+	}
+	return request
+}
+
+// AddWorklog logs seconds of work started at started against an issue
+func (client *JiraClient) AddWorklog(ctx context.Context, issueIDOrKey string, started time.Time, seconds int, comment string) (*Worklog, error) {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/worklog", issueIDOrKey)
+
+	resp, err := client.makeRequest(ctx, "POST", endpoint, worklogRequest(started, seconds, comment))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, client.apiError(resp, "add worklog")
+	}
+
+	var worklog Worklog
+	if err := json.NewDecoder(resp.Body).Decode(&worklog); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	return &worklog, nil
+}
+
+// UpdateWorklog changes the start, duration or comment of a worklog; zero values are left unchanged
+func (client *JiraClient) UpdateWorklog(ctx context.Context, issueIDOrKey, worklogID string, started time.Time, seconds int, comment string) (*Worklog, error) {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/worklog/%s", issueIDOrKey, worklogID)
+
+	resp, err := client.makeRequest(ctx, "PUT", endpoint, worklogRequest(started, seconds, comment))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.apiError(resp, "update worklog")
+	}
+
+	var worklog Worklog
+	if err := json.NewDecoder(resp.Body).Decode(&worklog); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	return &worklog, nil
+}
+
+// DeleteWorklog deletes a worklog from an issue
+func (client *JiraClient) DeleteWorklog(ctx context.Context, issueIDOrKey, worklogID string) error {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/worklog/%s", issueIDOrKey, worklogID)
+
+	resp, err := client.makeRequest(ctx, "DELETE", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return client.apiError(resp, "delete worklog")
+	}
+
+	return nil
+}
+
+// Working time used to convert days and weeks, the JIRA defaults
+const (
+	hoursPerDay = 8
+	daysPerWeek = 5
+)
+
+// durationUnits maps the JIRA duration units to seconds
+var durationUnits = map[byte]float64{
+	'w': daysPerWeek * hoursPerDay * 3600,
+	'd': hoursPerDay * 3600,
+	'h': 3600,
+	'm': 60,
+}
+
+// parseDuration parses a JIRA style duration such as "2h", "1h 30m", "1.5d" or "1w 2d"
+// into seconds. A day is 8 hours and a week 5 days, like JIRA's default time tracking.
+func parseDuration(value string) (int, error) {
+	fields := strings.Fields(strings.ToLower(value))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	// "1h30m" is accepted as well as "1h 30m"
+	var parts []string
+	for _, field := range fields {
+		start := 0
+		for i := 0; i < len(field); i++ {
+			if _, ok := durationUnits[field[i]]; ok {
+				parts = append(parts, field[start:i+1])
+				start = i + 1
+			}
+		}
+		if start != len(field) {
+			return 0, fmt.Errorf("invalid duration %q, expected e.g. 2h, 1h 30m or 1d", value)
+		}
+	}
+
+	var seconds float64
+	for _, part := range parts {
+		amount, err := strconv.ParseFloat(part[:len(part)-1], 64)
+		if err != nil || amount < 0 {
+			return 0, fmt.Errorf("invalid duration %q, expected e.g. 2h, 1h 30m or 1d", value)
+		}
+		seconds += amount * durationUnits[part[len(part)-1]]
+	}
+
+	if seconds < 60 {
+		return 0, fmt.Errorf("duration %q is shorter than a minute", value)
+	}
+	return int(seconds), nil
+}
+
+// formatDuration formats seconds as hours and minutes, e.g. "1h 30m"
+func formatDuration(seconds int) string {
+	hours, minutes := seconds/3600, seconds%3600/60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dh %dm", hours, minutes)
+}
+
+// parseStarted parses the start of a worklog given as a date, a date and time, or RFC 3339.
+// A bare date starts at 09:00 local time; an empty value is now.
+func parseStarted(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t.Add(9 * time.Hour), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid start %q, expected YYYY-MM-DD, \"YYYY-MM-DD HH:MM\" or RFC 3339", value)
+}
+
+// printWorklogs prints the worklogs of an issue as a table with a total
+func printWorklogs(worklogs []Worklog) {
+	if len(worklogs) == 0 {
+		fmt.Println("No work logged on this issue.")
+		return
+	}
+
+	total := 0
+	for _, w := range worklogs {
+		fmt.Printf("%-10s %-16s %-20s %8s  %s\n", w.ID, w.Started.Local().Format("2006-01-02 15:04"),
+			w.AuthorName(), formatDuration(w.TimeSpentSeconds), w.Comment)
+		total += w.TimeSpentSeconds
+	}
+	fmt.Printf("Total: %s\n", formatDuration(total))
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		seconds int
+		wantErr bool
+	}{
+		{value: "2h", seconds: 2 * 3600},
+		{value: "1h 30m", seconds: 5400},
+		{value: "1h30m", seconds: 5400},
+		{value: "45m", seconds: 45 * 60},
+		{value: "1.5d", seconds: 12 * 3600},
+		{value: "1d", seconds: 8 * 3600},
+		{value: "1w 2d", seconds: 7 * 8 * 3600},
+		{value: "1W 2D", seconds: 7 * 8 * 3600},
+		{value: "  3h  ", seconds: 3 * 3600},
+		{value: "", wantErr: true},
+		{value: "2", wantErr: true},
+		{value: "2x", wantErr: true},
+		{value: "h", wantErr: true},
+		{value: "1h 30", wantErr: true},
+		{value: "-1h", wantErr: true},
+		{value: "30s", wantErr: true},
+		{value: "0.5m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		seconds, err := parseDuration(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseDuration(%q) = %d, want an error", tt.value, seconds)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseDuration(%q) failed: %v", tt.value, err)
+			continue
+		}
+		if seconds != tt.seconds {
+			t.Errorf("parseDuration(%q) = %d, want %d", tt.value, seconds, tt.seconds)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    string
+	}{
+		{0, "0m"},
+		{45 * 60, "45m"},
+		{2 * 3600, "2h"},
+		{5400, "1h 30m"},
+		{5459, "1h 30m"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDuration(tt.seconds); got != tt.want {
+			t.Errorf("formatDuration(%d) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+func TestParseStarted(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "2026-10-16", want: time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)},
+		{value: "2026-10-16 14:30", want: time.Date(2026, 10, 16, 14, 30, 0, 0, time.Local)},
+		{value: "2026-10-16T14:30:00+02:00", want: time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)},
+		{value: "2026-10-16T14:30:00Z", want: time.Date(2026, 10, 16, 14, 30, 0, 0, time.UTC)},
+		{value: "16.10.2026", wantErr: true},
+		{value: "2026-13-01", wantErr: true},
+		{value: "2026-10-16 25:00", wantErr: true},
+		{value: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseStarted(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseStarted(%q) = %v, want an error", tt.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseStarted(%q) failed: %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseStarted(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseStartedEmptyIsNow(t *testing.T) {
+	before := time.Now()
+	got, err := parseStarted("")
+	if err != nil {
+		t.Fatalf("parseStarted(\"\") failed: %v", err)
+	}
+	if got.Before(before) || got.After(time.Now()) {
+		t.Errorf("parseStarted(\"\") = %v, want the current time", got)
+	}
+}